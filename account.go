@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Company is the Harvest account, which has its own web address separate
+// from the API host
+type Company struct {
+	Name    string `json:"name"`
+	BaseURI string `json:"base_uri"` // e.g. https://acme.harvestapp.com
+}
+
+// Result of looking up the account's web address. An empty baseURI means
+// it couldn't be loaded.
+type companyMsg struct{ baseURI string }
+
+// Fetch the account the access token belongs to
+func (h *HarvestClient) GetCompany() (*Company, error) {
+	resp, err := h.client.R().
+		SetResult(&Company{}).
+		Get("/company")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("API error: Status=%d, Body=%s", resp.StatusCode(), resp.String())
+	}
+
+	return resp.Result().(*Company), nil
+}
+
+// Load the web addresses cached by account ID, ignoring a missing or
+// corrupt file
+func loadAccounts() map[string]string {
+	accounts := make(map[string]string)
+	if !accountsFile.load(&accounts) {
+		return make(map[string]string)
+	}
+	return accounts
+}
+
+// Web address of the account: the configured subdomain wins, then the
+// cached address from an earlier run
+func accountWebBase(config Configuration) string {
+	if config.Subdomain != "" {
+		return "https://" + config.Subdomain + ".harvestapp.com"
+	}
+	return loadAccounts()[config.AccountID]
+}
+
+// Command to look up the account's web address. Failures leave URL
+// features disabled until the next start.
+func fetchWebBase(client *HarvestClient) tea.Cmd {
+	return func() tea.Msg {
+		company, err := client.GetCompany()
+		if err != nil || company.BaseURI == "" {
+			return companyMsg{}
+		}
+		return companyMsg{baseURI: strings.TrimRight(company.BaseURI, "/")}
+	}
+}
+
+// Command to cache the account's web address for the next start
+func cacheWebBase(accountID, baseURI string) tea.Cmd {
+	return func() tea.Msg {
+		accounts := loadAccounts()
+		accounts[accountID] = baseURI
+		return accountsFile.save(accounts)()
+	}
+}
+
+// Subdomain part of the web address, for display
+func webSubdomain(baseURI string) string {
+	u, err := url.Parse(baseURI)
+	if err != nil {
+		return ""
+	}
+	subdomain, _, _ := strings.Cut(u.Hostname(), ".")
+	return subdomain
+}
+
+// Command to look up the web address when neither configured nor cached
+func (m Model) lookupWebBase() tea.Cmd {
+	if m.webBase != "" {
+		return nil
+	}
+	return fetchWebBase(m.harvestClient)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Result of changing the running timer's billable status
+type billableMsg struct {
+	timerID  int
+	billable bool
+	err      string
+}
+
+// Flip the running timer's billable status right away and ask Harvest to
+// do the same, reverting if it refuses. Pressing the key again undoes it.
+func (m Model) toggleBillable() (tea.Model, tea.Cmd) {
+	if m.activeTimer == nil {
+		return m, nil
+	}
+
+	m.error = ""
+	m.activeTimer.Billable = !m.activeTimer.Billable
+	return m, updateBillable(m.harvestClient, m.activeTimer.ID, m.activeTimer.Billable)
+}
+
+// Command to set the billable status of a time entry
+func updateBillable(client *HarvestClient, timerID int, billable bool) tea.Cmd {
+	return func() tea.Msg {
+		entry, err := client.UpdateTimeEntry(timerID, map[string]any{"billable": billable})
+		if err != nil {
+			return billableMsg{timerID: timerID, billable: !billable, err: err.Error()}
+		}
+
+		// Harvest keeps the task's setting when it doesn't allow changing it
+		if entry.Billable != billable {
+			return billableMsg{timerID: timerID, billable: entry.Billable,
+				err: "Harvest doesn't allow changing billable for this task"}
+		}
+		return billableMsg{timerID: timerID, billable: billable}
+	}
+}
+
+// Apply the server's answer, which wins over the optimistic local change
+func (m *Model) applyBillable(msg billableMsg) {
+	if m.activeTimer == nil || m.activeTimer.ID != msg.timerID {
+		return
+	}
+
+	m.activeTimer.Billable = msg.billable
+	if msg.err != "" {
+		m.error = msg.err
+		return
+	}
+	m.success = fmt.Sprintf("Timer is now %s", billableLabel(msg.billable))
+}
+
+func billableLabel(billable bool) string {
+	if billable {
+		return "billable"
+	}
+	return "non-billable"
+}
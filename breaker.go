@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Defaults for the circuit breaker
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 60 * time.Second
+)
+
+// Circuit breaker states
+const (
+	breakerClosed   = "closed"    // requests go through
+	breakerOpen     = "open"      // requests fail fast until the cooldown ends
+	breakerHalfOpen = "half-open" // one probe request tests recovery
+)
+
+// Returned instead of sending a request while the breaker is open
+type circuitOpenError struct{ remaining time.Duration }
+
+func (e *circuitOpenError) Error() string {
+	if e.remaining <= 0 {
+		return "Harvest appears to be down — checking whether it's back"
+	}
+	return fmt.Sprintf("Harvest appears to be down — pausing requests for %ds", int(e.remaining.Round(time.Second).Seconds()))
+}
+
+// circuitBreaker stops sending requests after repeated failures, so a
+// Harvest outage isn't met with a stream of retries. Failures are network
+// errors and 5xx responses; after the cooldown a single probe decides
+// whether to resume. It is updated from resty hooks on the request
+// goroutines.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     string
+	failures  int // consecutive, while closed
+	openedAt  time.Time
+	probing   bool
+	probeAt   time.Time // when the probe was let through
+}
+
+func newCircuitBreaker(config Configuration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: intOr(config.BreakerThreshold, defaultBreakerThreshold),
+		cooldown:  durationOr(config.BreakerCooldownSeconds, defaultBreakerCooldown),
+		state:     breakerClosed,
+	}
+}
+
+// Let a request through, or refuse it while open. Once the cooldown has
+// passed the first request becomes the probe and the others wait on it.
+// The client has no request timeout, so a probe still unanswered after
+// another cooldown is given up on and the next request probes instead.
+func (b *circuitBreaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if remaining := b.cooldown - now.Sub(b.openedAt); remaining > 0 {
+			return &circuitOpenError{remaining: remaining}
+		}
+		b.state = breakerHalfOpen
+	case breakerHalfOpen:
+		if b.probing && now.Sub(b.probeAt) < b.cooldown {
+			return &circuitOpenError{remaining: 0}
+		}
+	default:
+		return nil
+	}
+
+	b.probing = true
+	b.probeAt = now
+	return nil
+}
+
+// Record a request that reached Harvest and got a healthy answer
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = breakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// Record a failed request, opening the breaker at the threshold or when
+// the probe fails
+func (b *circuitBreaker) failure(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = now
+		b.failures = 0
+		b.probing = false
+	}
+}
+
+// Hook the breaker into a resty client. Attach it before other request
+// hooks so refused requests aren't counted as sent.
+func (b *circuitBreaker) attach(client *resty.Client) {
+	client.OnBeforeRequest(func(*resty.Client, *resty.Request) error {
+		return b.allow(time.Now())
+	})
+	client.OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+		if resp.StatusCode() >= http.StatusInternalServerError {
+			b.failure(time.Now())
+		} else {
+			b.success()
+		}
+		return nil
+	})
+	client.OnError(func(_ *resty.Request, err error) {
+		var open *circuitOpenError
+		if errors.As(err, &open) {
+			return
+		}
+		// Errors after a response arrived, such as decoding, were already
+		// judged by the response hook
+		var respErr *resty.ResponseError
+		if errors.As(err, &respErr) && respErr.Response != nil && respErr.Response.RawResponse != nil {
+			return
+		}
+		b.failure(time.Now())
+	})
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func newTestBreaker() *circuitBreaker {
+	return newCircuitBreaker(Configuration{BreakerThreshold: 3, BreakerCooldownSeconds: 60})
+}
+
+// Open the breaker with threshold consecutive failures at now
+func tripBreaker(b *circuitBreaker, now time.Time) {
+	for range b.threshold {
+		b.failure(now)
+	}
+}
+
+func TestBreakerOpensAtThreshold(t *testing.T) {
+	b := newTestBreaker()
+	now := time.Now()
+
+	for i := 1; i < b.threshold; i++ {
+		b.failure(now)
+		if err := b.allow(now); err != nil {
+			t.Fatalf("refused after %d failures: %v", i, err)
+		}
+	}
+	b.failure(now)
+	if b.state != breakerOpen {
+		t.Fatalf("state = %q after %d failures, want open", b.state, b.threshold)
+	}
+}
+
+func TestBreakerSuccessResetsFailures(t *testing.T) {
+	b := newTestBreaker()
+	now := time.Now()
+
+	b.failure(now)
+	b.failure(now)
+	b.success()
+	b.failure(now)
+	if b.state != breakerClosed {
+		t.Errorf("state = %q, want closed since failures weren't consecutive", b.state)
+	}
+}
+
+func TestBreakerRefusesDuringCooldown(t *testing.T) {
+	b := newTestBreaker()
+	now := time.Now()
+	tripBreaker(b, now)
+
+	err := b.allow(now.Add(20 * time.Second))
+	var open *circuitOpenError
+	if !errors.As(err, &open) {
+		t.Fatalf("allow during cooldown = %v, want circuitOpenError", err)
+	}
+	if open.remaining != 40*time.Second {
+		t.Errorf("remaining = %s, want 40s", open.remaining)
+	}
+}
+
+func TestBreakerSingleProbe(t *testing.T) {
+	b := newTestBreaker()
+	now := time.Now()
+	tripBreaker(b, now)
+	after := now.Add(b.cooldown)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if b.allow(after) == nil {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 1 {
+		t.Errorf("%d of %d concurrent callers allowed after the cooldown, want a single probe", allowed, callers)
+	}
+	if b.state != breakerHalfOpen {
+		t.Errorf("state = %q, want half-open", b.state)
+	}
+}
+
+func TestBreakerProbeSuccessCloses(t *testing.T) {
+	b := newTestBreaker()
+	now := time.Now()
+	tripBreaker(b, now)
+	after := now.Add(b.cooldown)
+
+	if err := b.allow(after); err != nil {
+		t.Fatalf("probe refused: %v", err)
+	}
+	b.success()
+
+	if b.state != breakerClosed {
+		t.Errorf("state = %q, want closed", b.state)
+	}
+	for range 3 {
+		if err := b.allow(after); err != nil {
+			t.Errorf("refused after recovery: %v", err)
+		}
+	}
+}
+
+func TestBreakerProbeFailureReopens(t *testing.T) {
+	b := newTestBreaker()
+	now := time.Now()
+	tripBreaker(b, now)
+	after := now.Add(b.cooldown)
+
+	if err := b.allow(after); err != nil {
+		t.Fatalf("probe refused: %v", err)
+	}
+	b.failure(after)
+
+	if b.state != breakerOpen {
+		t.Fatalf("state = %q after a failed probe, want open", b.state)
+	}
+	if err := b.allow(after.Add(time.Second)); err == nil {
+		t.Error("allowed right after a failed probe, want a fresh cooldown")
+	}
+	if err := b.allow(after.Add(b.cooldown)); err != nil {
+		t.Errorf("no probe after the second cooldown: %v", err)
+	}
+}
+
+func TestBreakerStalledProbeExpires(t *testing.T) {
+	b := newTestBreaker()
+	now := time.Now()
+	tripBreaker(b, now)
+	after := now.Add(b.cooldown)
+
+	if err := b.allow(after); err != nil {
+		t.Fatalf("probe refused: %v", err)
+	}
+	// The probe never answers
+	if err := b.allow(after.Add(b.cooldown - time.Second)); err == nil {
+		t.Error("second request allowed while the probe may still answer")
+	}
+	if err := b.allow(after.Add(b.cooldown)); err != nil {
+		t.Errorf("still refused a cooldown after the probe stalled: %v", err)
+	}
+	if err := b.allow(after.Add(b.cooldown)); err == nil {
+		t.Error("allowed two requests after giving up on the probe, want one new probe")
+	}
+}
+
+func TestBreakerHooks(t *testing.T) {
+	var hits atomic.Int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	b := newTestBreaker()
+	client := resty.New().SetBaseURL(server.URL)
+	b.attach(client)
+
+	for range b.threshold {
+		if _, err := client.R().Get("/users/me"); err != nil {
+			t.Fatalf("request refused before the threshold: %v", err)
+		}
+	}
+	_, err := client.R().Get("/users/me")
+	var open *circuitOpenError
+	if !errors.As(err, &open) {
+		t.Fatalf("request after %d server errors = %v, want circuitOpenError", b.threshold, err)
+	}
+	if n := hits.Load(); n != int32(b.threshold) {
+		t.Errorf("server hit %d times, want %d with the breaker open", n, b.threshold)
+	}
+
+	// Harvest is back once the cooldown has passed
+	b.mu.Lock()
+	b.openedAt = time.Now().Add(-b.cooldown)
+	b.mu.Unlock()
+	healthy.Store(true)
+
+	if _, err := client.R().Get("/users/me"); err != nil {
+		t.Fatalf("probe refused: %v", err)
+	}
+	if b.state != breakerClosed {
+		t.Errorf("state = %q after a healthy probe, want closed", b.state)
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Width of the estimate progress bar in cells
+const budgetBarWidth = 20
+
+// projectBudget is the estimate of a project and the hours tracked to it
+type projectBudget struct {
+	estimate float64
+	tracked  float64
+}
+
+// Budgets fetched for the project list: hour budgets from Harvest, and
+// the entries of projects with configured estimates, only those updated
+// since the last sync when there was one
+type budgetsMsg struct {
+	budgets  map[int]projectBudget
+	hours    map[int]map[int]float64 // project ID to hours by entry ID
+	full     map[int]bool            // projects fetched in full
+	syncedAt time.Time
+	failed   []string // projects whose hours couldn't be loaded
+}
+
+// projectHours caches the hours of a project's entries by entry ID, so
+// refreshes only fetch entries updated since lastSync. Like entryCache it
+// misses entries deleted elsewhere until the next restart.
+type projectHours struct {
+	entries  map[int]float64
+	lastSync time.Time
+}
+
+// Hours budget of a project from Harvest's project budget report
+type ProjectBudget struct {
+	ProjectID   int     `json:"project_id"`
+	BudgetBy    string  `json:"budget_by"`
+	Budget      float64 `json:"budget"`
+	BudgetSpent float64 `json:"budget_spent"`
+}
+
+// Fetch the project budget report. It needs manager or admin permissions,
+// so callers should treat an error as "no budgets".
+func (h *HarvestClient) GetProjectBudgets() ([]ProjectBudget, error) {
+	var budgets []ProjectBudget
+
+	for page := 1; ; page++ {
+		var result struct {
+			Results  []ProjectBudget `json:"results"`
+			NextPage *int            `json:"next_page"`
+		}
+
+		resp, err := h.client.R().
+			SetResult(&result).
+			Get(fmt.Sprintf("/reports/project_budget?page=%d&per_page=%d", page, h.config.perPage()))
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.IsError() {
+			return nil, fmt.Errorf("API error: Status=%d, Body=%s", resp.StatusCode(), resp.String())
+		}
+
+		budgets = append(budgets, result.Results...)
+		if result.NextPage == nil {
+			return budgets, nil
+		}
+	}
+}
+
+// Hours of the entries tracked to a project by entry ID, only those
+// created or changed after updatedSince unless it is zero
+func (h *HarvestClient) GetProjectHours(projectID int, updatedSince time.Time) (map[int]float64, error) {
+	hours := make(map[int]float64)
+
+	query := fmt.Sprintf("project_id=%d", projectID)
+	if !updatedSince.IsZero() {
+		query += "&updated_since=" + url.QueryEscape(updatedSince.UTC().Format(time.RFC3339))
+	}
+
+	for page := 1; ; page++ {
+		var result struct {
+			TimeEntries []TimeEntry `json:"time_entries"`
+			NextPage    *int        `json:"next_page"`
+		}
+
+		resp, err := h.client.R().
+			SetResult(&result).
+			Get(fmt.Sprintf("/time_entries?%s&page=%d&per_page=%d", query, page, h.config.perPage()))
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.IsError() {
+			return nil, fmt.Errorf("API error: Status=%d, Body=%s", resp.StatusCode(), resp.String())
+		}
+
+		for _, entry := range result.TimeEntries {
+			hours[entry.ID] = entry.Hours
+		}
+		if result.NextPage == nil {
+			return hours, nil
+		}
+	}
+}
+
+// Command to fetch budgets for the listed projects. Estimates from the
+// config take precedence over hour budgets set in Harvest. A project whose
+// hours fail to load is reported without losing the other budgets.
+func (m Model) fetchBudgets() tea.Cmd {
+	client, estimates, projects := m.harvestClient, m.config.Estimates, m.projects
+
+	since := make(map[int]time.Time)
+	for projectID, cache := range m.projectHours {
+		since[projectID] = cache.lastSync
+	}
+
+	return func() tea.Msg {
+		// Taken before the requests so nothing updated during them is missed
+		msg := budgetsMsg{
+			budgets:  make(map[int]projectBudget),
+			hours:    make(map[int]map[int]float64),
+			full:     make(map[int]bool),
+			syncedAt: time.Now(),
+		}
+
+		listed := make(map[int]string)
+		for _, project := range projects {
+			listed[project.ID] = project.Name
+		}
+
+		if report, err := client.GetProjectBudgets(); err == nil {
+			for _, b := range report {
+				// Only hour budgets compare to tracked time, not fee budgets
+				switch b.BudgetBy {
+				case "project", "task", "person":
+					if _, ok := listed[b.ProjectID]; ok && b.Budget > 0 {
+						msg.budgets[b.ProjectID] = projectBudget{estimate: b.Budget, tracked: b.BudgetSpent}
+					}
+				}
+			}
+		}
+
+		for projectID, estimate := range estimates {
+			name, ok := listed[projectID]
+			if !ok || estimate <= 0 {
+				continue
+			}
+			hours, err := client.GetProjectHours(projectID, since[projectID])
+			if err != nil {
+				msg.failed = append(msg.failed, name)
+				continue
+			}
+			msg.hours[projectID] = hours
+			msg.full[projectID] = since[projectID].IsZero()
+		}
+		sort.Strings(msg.failed)
+
+		return msg
+	}
+}
+
+// Merge fetched hours into the cache and set the budgets, estimates from
+// the cache winning over Harvest's
+func (m *Model) applyBudgets(msg budgetsMsg) {
+	if m.projectHours == nil {
+		m.projectHours = make(map[int]*projectHours)
+	}
+	for projectID, hours := range msg.hours {
+		cache, ok := m.projectHours[projectID]
+		if !ok || msg.full[projectID] {
+			cache = &projectHours{entries: make(map[int]float64)}
+			m.projectHours[projectID] = cache
+		}
+		maps.Copy(cache.entries, hours)
+		cache.lastSync = msg.syncedAt
+	}
+
+	m.budgets = msg.budgets
+	for projectID, estimate := range m.config.Estimates {
+		cache, ok := m.projectHours[projectID]
+		if !ok || estimate <= 0 {
+			continue
+		}
+		var tracked float64
+		for _, hours := range cache.entries {
+			tracked += hours
+		}
+		m.budgets[projectID] = projectBudget{estimate: estimate, tracked: tracked}
+	}
+
+	if len(msg.failed) > 0 {
+		m.error = "Failed to load tracked hours for " + strings.Join(msg.failed, ", ")
+	}
+}
+
+// Fraction of the estimate used so far
+func (b projectBudget) used() float64 {
+	return b.tracked / b.estimate
+}
+
+// Short "12.5/40h (31%)" summary for list items
+func (b projectBudget) summary() string {
+	return fmt.Sprintf("%.1f/%.0fh (%.0f%%)", b.tracked, b.estimate, b.used()*100)
+}
+
+// Progress bar of the estimate used, colored by how much is consumed
+func (b projectBudget) bar() string {
+	filled := min(int(b.used()*budgetBarWidth), budgetBarWidth)
+
+	color := colorSuccess
+	switch used := b.used(); {
+	case used >= 1:
+		color = colorError
+	case used >= 0.75:
+		color = colorWarning
+	}
+
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", budgetBarWidth-filled)
+	return lipgloss.NewStyle().Foreground(color).Render(bar) + " " + b.summary()
+}
+
+// Budget of a project including the running timer, which the fetched
+// totals only count up to the hours it had when they were fetched
+func (m Model) liveBudget(projectID int) (projectBudget, bool) {
+	b, ok := m.budgets[projectID]
+	if ok && m.activeTimer != nil && m.activeTimer.ProjectID == projectID {
+		b.tracked += max(m.elapsed().Hours()-m.activeTimer.Hours, 0)
+	}
+	return b, ok
+}
+
+// Estimate line for the selected project, empty when it has no estimate
+func (m Model) budgetView(projectID int) string {
+	b, ok := m.liveBudget(projectID)
+	if !ok {
+		return ""
+	}
+	return "Estimate: " + b.bar() + "\n"
+}
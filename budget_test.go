@@ -0,0 +1,96 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLiveBudgetAddsRunningTimer(t *testing.T) {
+	m := Model{
+		budgets:        map[int]projectBudget{11: {estimate: 10, tracked: 4}, 12: {estimate: 5, tracked: 1}},
+		activeTimer:    &Timer{ProjectID: 11, Hours: 0.5},
+		timerStartedAt: time.Now().Add(-2 * time.Hour),
+	}
+
+	// The fetched total already has the timer's 0.5h, the other 1.5h is live
+	b, ok := m.liveBudget(11)
+	if !ok || math.Abs(b.tracked-5.5) > 0.01 {
+		t.Errorf("running project: got %v (%v), want 5.5h", b.tracked, ok)
+	}
+
+	if b, _ := m.liveBudget(12); b.tracked != 1 {
+		t.Errorf("other project: got %v, want 1h", b.tracked)
+	}
+	if _, ok := m.liveBudget(13); ok {
+		t.Error("project without an estimate has a budget")
+	}
+}
+
+func TestFetchBudgetsKeepsLoadedBudgets(t *testing.T) {
+	var mu sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v2/reports/project_budget" {
+			w.Write([]byte(`{"results": [{"project_id": 3, "budget_by": "project", "budget": 20, "budget_spent": 5}]}`))
+			return
+		}
+
+		query := r.URL.Query()
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+		switch {
+		case query.Get("project_id") == "1":
+			w.WriteHeader(http.StatusInternalServerError)
+		case query.Has("updated_since"):
+			w.Write([]byte(`{"time_entries": [{"id": 21, "hours": 1.5}, {"id": 23, "hours": 1}]}`))
+		default:
+			w.Write([]byte(`{"time_entries": [{"id": 21, "hours": 1}, {"id": 22, "hours": 2}]}`))
+		}
+	}))
+	defer server.Close()
+
+	m := Model{
+		harvestClient: NewHarvestClient(Configuration{BaseURL: server.URL + "/v2"}),
+		config:        Configuration{Estimates: map[int]float64{1: 10, 2: 8}},
+		projects:      []Project{{ID: 1, Name: "Website"}, {ID: 2, Name: "Mobile App"}, {ID: 3, Name: "Intranet"}},
+	}
+
+	m.applyBudgets(m.fetchBudgets()().(budgetsMsg))
+	if _, ok := m.budgets[1]; ok {
+		t.Error("budget for the project that failed to load")
+	}
+	if b := m.budgets[2]; b.estimate != 8 || b.tracked != 3 {
+		t.Errorf("Mobile App budget = %+v, want 3 of 8h", b)
+	}
+	if b := m.budgets[3]; b.estimate != 20 || b.tracked != 5 {
+		t.Errorf("Intranet budget = %+v, want Harvest's 5 of 20h", b)
+	}
+	if !strings.Contains(m.error, "Website") {
+		t.Errorf("error = %q, want the failed project named", m.error)
+	}
+
+	// The refresh only asks for entries updated since the first sync
+	m.applyBudgets(m.fetchBudgets()().(budgetsMsg))
+	if b := m.budgets[2]; b.tracked != 4.5 {
+		t.Errorf("Mobile App tracked %vh after the delta, want 4.5h", b.tracked)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var mobile []string
+	for _, query := range queries {
+		if strings.Contains(query, "project_id=2") {
+			mobile = append(mobile, query)
+		}
+	}
+	if len(mobile) != 2 || strings.Contains(mobile[0], "updated_since") || !strings.Contains(mobile[1], "updated_since") {
+		t.Errorf("Mobile App queries %q, want a full fetch then a delta", mobile)
+	}
+}
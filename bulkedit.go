@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Result of updating one entry in a bulk edit
+type bulkEditMsg struct {
+	entry TimeEntry
+	err   string
+}
+
+// Toggle the summary entry under the cursor in the bulk edit selection
+func (m *Model) toggleEntrySelection() {
+	entry, ok := m.selectedEntry()
+	if !ok {
+		return
+	}
+
+	if m.summarySelected == nil {
+		m.summarySelected = make(map[int]bool)
+	}
+	if m.summarySelected[entry.ID] {
+		delete(m.summarySelected, entry.ID)
+	} else {
+		m.summarySelected[entry.ID] = true
+	}
+}
+
+// Open the bulk edit prompt for the selected entries, or the one under the
+// cursor when none are selected
+func (m Model) openBulkEdit() (tea.Model, tea.Cmd) {
+	if m.refuseInSafeMode() {
+		return m, nil
+	}
+	if len(m.bulkQueue) > 0 {
+		m.error = "A bulk edit is still running"
+		return m, nil
+	}
+
+	if len(m.summarySelected) == 0 {
+		m.toggleEntrySelection()
+	}
+	if len(m.summarySelected) == 0 {
+		return m, nil
+	}
+
+	m.error = ""
+	m.success = ""
+	m.bulkInput.SetValue("")
+	m.bulkInput.Focus()
+	m.bulkReturn = m.state
+	m.state = "bulk_edit"
+	return m, textinput.Blink
+}
+
+// Handle the bulk edit prompt
+func (m Model) updateBulkEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.bulkInput.Blur()
+		m.state = m.bulkReturn
+		return m, nil
+	case "enter":
+		edit := strings.TrimSpace(m.bulkInput.Value())
+		if edit == "" {
+			m.error = "Enter new notes, +text to append or find => replace"
+			return m, nil
+		}
+		// An empty find would insert the replacement between every
+		// character of the notes
+		if find, _, ok := strings.Cut(edit, "=>"); ok && strings.TrimSpace(find) == "" {
+			m.error = "Enter the text to find before =>"
+			return m, nil
+		}
+
+		m.bulkInput.Blur()
+		m.state = m.bulkReturn
+		m.bulkLog = nil
+		m.bulkQueue = nil
+		for _, entry := range m.summaryEntries {
+			if !m.summarySelected[entry.ID] {
+				continue
+			}
+
+			// Harvest rejects changes to locked entries
+			if entry.IsLocked || entry.IsBilled {
+				m.bulkLog = append(m.bulkLog, fmt.Sprintf("skipped %s / %s: locked", entry.Project.Name, entry.Task.Name))
+				continue
+			}
+
+			notes := bulkNotes(entry.Notes, edit, m.config.noteSeparator())
+			if notes == entry.Notes {
+				m.bulkLog = append(m.bulkLog, fmt.Sprintf("skipped %s / %s: unchanged", entry.Project.Name, entry.Task.Name))
+				continue
+			}
+			entry.Notes = notes
+			m.bulkQueue = append(m.bulkQueue, entry)
+		}
+		m.bulkTotal = len(m.bulkQueue)
+		m.summarySelected = nil
+		return m, m.nextBulkEdit()
+	}
+
+	var cmd tea.Cmd
+	m.bulkInput, cmd = m.bulkInput.Update(msg)
+	return m, cmd
+}
+
+// New notes for an entry: "+text" appends to the notes, "find => replace"
+// replaces text within them, anything else replaces them entirely
+func bulkNotes(notes, edit, separator string) string {
+	if text, ok := strings.CutPrefix(edit, "+"); ok {
+		return appendNotes(notes, text, separator)
+	}
+	if find, replace, ok := strings.Cut(edit, "=>"); ok {
+		return sanitizeNotes(strings.ReplaceAll(notes, strings.TrimSpace(find), strings.TrimSpace(replace)))
+	}
+	return sanitizeNotes(edit)
+}
+
+// Command to update the next queued entry. Entries are updated one at a
+// time so progress can be shown and failures reported per entry.
+func (m Model) nextBulkEdit() tea.Cmd {
+	if len(m.bulkQueue) == 0 {
+		return nil
+	}
+
+	client, entry := m.harvestClient, m.bulkQueue[0]
+	return func() tea.Msg {
+		if _, err := client.UpdateTimeEntry(entry.ID, map[string]any{"notes": entry.Notes}); err != nil {
+			return bulkEditMsg{entry: entry, err: err.Error()}
+		}
+		return bulkEditMsg{entry: entry}
+	}
+}
+
+// Record the result of one entry and move on to the next
+func (m Model) handleBulkEdit(msg bulkEditMsg) (tea.Model, tea.Cmd) {
+	if len(m.bulkQueue) > 0 {
+		m.bulkQueue = m.bulkQueue[1:]
+	}
+
+	label := msg.entry.Project.Name + " / " + msg.entry.Task.Name
+	if msg.err != "" {
+		m.bulkLog = append(m.bulkLog, fmt.Sprintf("failed %s: %s", label, msg.err))
+	} else {
+		m.bulkLog = append(m.bulkLog, fmt.Sprintf("updated %s — %s", label, msg.entry.Notes))
+	}
+
+	if len(m.bulkQueue) > 0 {
+		return m, m.nextBulkEdit()
+	}
+
+	// Pick up the new notes in the summary and today panel
+	return m, tea.Batch(m.syncSummary(), m.refreshToday())
+}
+
+// Progress and per-entry results of the last bulk edit
+func (m Model) bulkEditView() string {
+	if len(m.bulkLog) == 0 && len(m.bulkQueue) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	if len(m.bulkQueue) > 0 {
+		fmt.Fprintf(&b, "\nUpdating %d of %d entries...\n", m.bulkTotal-len(m.bulkQueue)+1, m.bulkTotal)
+	} else {
+		b.WriteString("\nBulk edit finished\n")
+	}
+	for _, line := range m.bulkLog {
+		b.WriteString(infoStyle.Render("  "+line) + "\n")
+	}
+	return b.String()
+}
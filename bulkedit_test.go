@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBulkEditRejectsEmptyFind(t *testing.T) {
+	for _, edit := range []string{"=> x", " => x", "=>"} {
+		m := newTestModel(t)
+		m.state = "bulk_edit"
+		m.summaryEntries = []TimeEntry{{ID: 1, Notes: "Standup"}}
+		m.summarySelected = map[int]bool{1: true}
+		m.bulkInput.SetValue(edit)
+
+		model, cmd := m.updateBulkEdit(tea.KeyMsg{Type: tea.KeyEnter})
+		got := model.(Model)
+		if cmd != nil || len(got.bulkQueue) != 0 {
+			t.Errorf("%q queued %+v, want nothing sent", edit, got.bulkQueue)
+		}
+		if got.error == "" {
+			t.Errorf("%q gave no error", edit)
+		}
+		if !got.summarySelected[1] {
+			t.Errorf("%q dropped the marked entries", edit)
+		}
+	}
+}
+
+func TestBulkNotes(t *testing.T) {
+	tests := []struct {
+		notes, edit, want string
+	}{
+		{"Standup", "Planning", "Planning"},
+		{"Standup", "+notes", "Standup · notes"},
+		{"TICKET-1 - Standup", "TICKET-1 => TICKET-2", "TICKET-2 - Standup"},
+	}
+	for _, tt := range tests {
+		if got := bulkNotes(tt.notes, tt.edit, " · "); got != tt.want {
+			t.Errorf("bulkNotes(%q, %q) = %q, want %q", tt.notes, tt.edit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cacheFile is a JSON file in the cache directory holding state kept
+// between runs, such as the notes draft or history. Losing it is harmless,
+// so a missing or corrupt file loads as nothing.
+type cacheFile string
+
+const (
+	draftFile    cacheFile = "draft.json"
+	historyFile  cacheFile = "history.json"
+	windowFile   cacheFile = "window.json"
+	recentFile   cacheFile = "recent.json"
+	accountsFile cacheFile = "accounts.json"
+)
+
+// Reports a cache file that couldn't be written
+type cacheErrorMsg struct{ err error }
+
+// Get the path of the cache file
+func (f cacheFile) path() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "harvest-tui", string(f)), nil
+}
+
+// Decode the file into v, returning false when it is missing or corrupt
+func (f cacheFile) load(v any) bool {
+	path, err := f.path()
+	if err != nil {
+		return false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// Command to write v to the file. It is encoded right away, so v may
+// change once the command is returned.
+func (f cacheFile) save(v any) tea.Cmd {
+	data, err := json.Marshal(v)
+	return func() tea.Msg {
+		if err == nil {
+			err = f.write(data)
+		}
+		if err != nil {
+			return cacheErrorMsg{fmt.Errorf("Failed to save %s: %v", f, err)}
+		}
+		return nil
+	}
+}
+
+// Command to remove the file
+func (f cacheFile) remove() tea.Cmd {
+	return func() tea.Msg {
+		path, err := f.path()
+		if err == nil {
+			err = os.Remove(path)
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return cacheErrorMsg{fmt.Errorf("Failed to remove %s: %v", f, err)}
+		}
+		return nil
+	}
+}
+
+func (f cacheFile) write(data []byte) error {
+	path, err := f.path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCacheFileRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	history := notesHistory{historyKey(1, 2): {"Newest", "Older"}}
+	if msg := historyFile.save(history)(); msg != nil {
+		t.Fatalf("save = %+v", msg)
+	}
+	if got := loadHistory(); len(got[historyKey(1, 2)]) != 2 {
+		t.Errorf("loaded %+v, want the saved history", got)
+	}
+
+	if msg := saveDraft(draft{})(); msg != nil {
+		t.Fatalf("removing the missing draft = %+v", msg)
+	}
+	if _, ok := loadDraft(); ok {
+		t.Error("draft loaded after removing it")
+	}
+}
+
+func TestCacheFileCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	os.MkdirAll(filepath.Join(dir, "harvest-tui"), 0o700)
+	os.WriteFile(filepath.Join(dir, "harvest-tui", string(windowFile)), []byte("{"), 0o600)
+
+	if size, ok := loadWindowSize(); ok {
+		t.Errorf("corrupt file loaded as %+v", size)
+	}
+}
+
+func TestCacheFileReportsWriteErrors(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+
+	// A file where the cache directory should be
+	if err := os.WriteFile(filepath.Join(dir, "harvest-tui"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, ok := recentFile.save([]recentUse{{Project: Project{ID: 1}}})().(cacheErrorMsg)
+	if !ok {
+		t.Fatal("write error not reported")
+	}
+
+	m := newTestModel(t)
+	model, _ := m.Update(msg)
+	if model.(Model).error == "" {
+		t.Error("write error not shown")
+	}
+}
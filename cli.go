@@ -0,0 +1,416 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Run a non-interactive subcommand and return the process exit code
+func runCommand(args []string) int {
+	switch args[0] {
+	case "start":
+		return runStart(args[1:])
+	case "log":
+		return runLog(args[1:])
+	case "import":
+		return runImport(args[1:])
+	case "report":
+		return runReport(args[1:])
+	case "alias":
+		return runAlias(args[1:])
+	case "keys":
+		return runKeys(args[1:])
+	case "doctor":
+		return runDoctor(args[1:])
+	case "help", "-h", "--help":
+		printUsage()
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", args[0])
+		printUsage()
+		return 2
+	}
+}
+
+func printUsage() {
+	fmt.Fprint(os.Stderr, `Usage:
+  harvest-tui [--color=auto|always|never] [--diagnostics] [--safe-mode] [command]
+  harvest-tui                      Launch the interactive TUI
+  harvest-tui start <alias>        Start a timer for an alias
+  harvest-tui start --project X --task Y [--notes N] [--first]
+                                   Start a timer by project/task ID or name
+  harvest-tui log [<alias>|--project X --task Y [--first]] --hours H [--date D] [--notes N]
+                                   Log a completed entry
+  harvest-tui import [--no-input] [--on-duplicate=skip|overwrite|create] FILE.csv
+                                   Import completed entries from CSV
+  harvest-tui report [--period day|week|month] [--date D] [--format F] [--out FILE]
+                   [--to-clipboard] [--no-hours]
+                                   Print entries as csv, tsv, json, markdown, timesheet or email
+  harvest-tui alias list           List configured aliases
+  harvest-tui keys [--json]        Print the effective key bindings
+  harvest-tui doctor [--adopt-timezone] [--diagnostics]
+                                   Check the config, connection and time zone
+`)
+}
+
+// Load the configuration and credentials for a subcommand
+func commandConfig() (Configuration, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return config, err
+	}
+
+	config.AccountID = os.Getenv("HARVEST_ACCOUNT_ID")
+	config.AccessToken = os.Getenv("HARVEST_ACCESS_TOKEN")
+	if config.AccountID == "" || config.AccessToken == "" {
+		return config, fmt.Errorf("HARVEST_ACCOUNT_ID and HARVEST_ACCESS_TOKEN environment variables must be set")
+	}
+
+	if err := applyTimezone(config.Timezone); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+// Start a timer without launching the TUI
+func runStart(args []string) int {
+	// A leading alias may be followed by flags
+	var aliasName string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		aliasName, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("start", flag.ContinueOnError)
+	projectArg := fs.String("project", "", "project ID or name")
+	taskArg := fs.String("task", "", "task ID or name")
+	first := fs.Bool("first", false, "take the first match when a name matches several projects or tasks")
+	notes := fs.String("notes", "", `timer notes, or "-" to read them from stdin`)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if aliasName == "" && (*projectArg == "" || *taskArg == "") {
+		fmt.Fprintln(os.Stderr, "start requires an alias or both --project and --task")
+		return 2
+	}
+
+	notesArg, err := readNotes(*notes)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	config, err := commandConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	client := NewHarvestClient(config)
+
+	target, err := resolveTarget(client, config, aliasName, *projectArg, *taskArg, *first)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	timer, err := client.StartTimer(target.ProjectID, target.TaskID, notesArg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Printf("Started timer %d for %s / %s\n", timer.ID, target.ProjectName, target.TaskName)
+	return 0
+}
+
+// Log a completed time entry without launching the TUI
+func runLog(args []string) int {
+	var aliasName string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		aliasName, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("log", flag.ContinueOnError)
+	projectArg := fs.String("project", "", "project ID or name")
+	taskArg := fs.String("task", "", "task ID or name")
+	first := fs.Bool("first", false, "take the first match when a name matches several projects or tasks")
+	date := fs.String("date", time.Now().Format("2006-01-02"), "spent date (YYYY-MM-DD)")
+	hours := fs.Float64("hours", 0, "hours to log")
+	notes := fs.String("notes", "", `entry notes, or "-" to read them from stdin`)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if aliasName == "" && (*projectArg == "" || *taskArg == "") {
+		fmt.Fprintln(os.Stderr, "log requires an alias or both --project and --task")
+		return 2
+	}
+	if *hours <= 0 || *hours > 24 {
+		fmt.Fprintln(os.Stderr, "--hours must be greater than 0 and at most 24")
+		return 2
+	}
+	if _, err := time.Parse("2006-01-02", *date); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --date %q, expected YYYY-MM-DD\n", *date)
+		return 2
+	}
+
+	notesArg, err := readNotes(*notes)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	config, err := commandConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	client := NewHarvestClient(config)
+
+	target, err := resolveTarget(client, config, aliasName, *projectArg, *taskArg, *first)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	entry, err := client.CreateTimeEntry(target.ProjectID, target.TaskID, *date, *hours, notesArg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Println(entry.ID)
+	return 0
+}
+
+// Resolve the --notes flag, reading stdin when it is "-"
+func readNotes(value string) (string, error) {
+	if value != "-" {
+		return sanitizeNotes(value), nil
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read notes from stdin: %v", err)
+	}
+
+	notes := sanitizeNotes(string(data))
+	if notes == "" {
+		return "", fmt.Errorf("--notes - was given but stdin was empty")
+	}
+
+	return notes, nil
+}
+
+// Manage project/task aliases
+func runAlias(args []string) int {
+	if len(args) == 0 || args[0] != "list" {
+		fmt.Fprintln(os.Stderr, "Usage: harvest-tui alias list")
+		return 2
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	printAliases(os.Stdout, config.Aliases)
+	return 0
+}
+
+// Print the effective key bindings, including config overrides
+func runKeys(args []string) int {
+	fs := flag.NewFlagSet("keys", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	keys, err := newKeyMap(config.Keys)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(keys.table()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		return 0
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTION\tKEYS\tDESCRIPTION")
+	for _, binding := range keys.table() {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", binding.Action, strings.Join(binding.Keys, ", "), binding.Description)
+	}
+	w.Flush()
+	return 0
+}
+
+// Print aliases as a table, sorted by name
+func printAliases(out io.Writer, aliases map[string]Alias) {
+	if len(aliases) == 0 {
+		fmt.Fprintln(out, "No aliases configured. Press a on a task in the TUI to create one.")
+		return
+	}
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ALIAS\tPROJECT\tTASK")
+	for _, name := range names {
+		alias := aliases[name]
+		fmt.Fprintf(w, "%s\t%s (%d)\t%s (%d)\n",
+			name, alias.ProjectName, alias.ProjectID, alias.TaskName, alias.TaskID)
+	}
+	w.Flush()
+}
+
+// Resolve an alias, or project and task arguments, to the entry target
+func resolveTarget(client *HarvestClient, config Configuration, aliasName, projectArg, taskArg string, first bool) (Alias, error) {
+	if aliasName != "" {
+		alias, ok := config.Aliases[strings.ToLower(aliasName)]
+		if !ok {
+			var available strings.Builder
+			printAliases(&available, config.Aliases)
+			return Alias{}, fmt.Errorf("Unknown alias %q\n\n%s", aliasName, strings.TrimRight(available.String(), "\n"))
+		}
+		return alias, nil
+	}
+
+	project, err := resolveProject(client, projectArg, first)
+	if err != nil {
+		return Alias{}, err
+	}
+	task, err := resolveTask(client, project.ID, taskArg, first)
+	if err != nil {
+		return Alias{}, err
+	}
+
+	return Alias{
+		ProjectID:   project.ID,
+		TaskID:      task.ID,
+		ProjectName: project.Name,
+		TaskName:    task.Name,
+	}, nil
+}
+
+// Find a project by ID or case-insensitive name, see resolveByName
+func resolveProject(client *HarvestClient, query string, first bool) (Project, error) {
+	projects, _, err := client.GetProjects()
+	if err != nil {
+		return Project{}, err
+	}
+
+	project, err := resolveByName(projects, query, first, "project",
+		func(p Project) int { return p.ID }, func(p Project) string { return p.Name })
+	return project, withFirstHint(err)
+}
+
+// Find a task of a project by ID or case-insensitive name, see resolveByName
+func resolveTask(client *HarvestClient, projectID int, query string, first bool) (Task, error) {
+	tasks, err := client.GetTasks(projectID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	task, err := resolveByName(tasks, query, first, "task",
+		func(t Task) int { return t.ID }, func(t Task) string { return t.Name })
+	return task, withFirstHint(err)
+}
+
+// Point at --first when a name matched several items
+func withFirstHint(err error) error {
+	var ambiguous *ambiguousError
+	if errors.As(err, &ambiguous) {
+		return fmt.Errorf("%v, or --first to take the first one", err)
+	}
+	return err
+}
+
+// ambiguousError is a name matching several items, listed by ID and name
+type ambiguousError struct {
+	message string
+}
+
+func (e *ambiguousError) Error() string {
+	return e.message
+}
+
+// Pick the item a query names. An ID wins, then an exact name, then a name
+// containing the query. Several matches at the same level are an
+// ambiguousError listing them, unless first is set, which takes the first
+// by name and ID.
+func resolveByName[T any](items []T, query string, first bool, kind string, id func(T) int, name func(T) string) (T, error) {
+	var zero T
+
+	if n, err := strconv.Atoi(query); err == nil {
+		for _, item := range items {
+			if id(item) == n {
+				return item, nil
+			}
+		}
+	}
+
+	lower := strings.ToLower(query)
+	matches := func(match func(string) bool) []T {
+		var found []T
+		for _, item := range items {
+			if match(strings.ToLower(name(item))) {
+				found = append(found, item)
+			}
+		}
+		sort.Slice(found, func(i, j int) bool {
+			if name(found[i]) != name(found[j]) {
+				return name(found[i]) < name(found[j])
+			}
+			return id(found[i]) < id(found[j])
+		})
+		return found
+	}
+
+	found := matches(func(n string) bool { return n == lower })
+	if len(found) == 0 {
+		found = matches(func(n string) bool { return strings.Contains(n, lower) })
+	}
+
+	switch {
+	case len(found) == 0:
+		return zero, fmt.Errorf("No %s matching %q", kind, query)
+	case len(found) == 1 || first:
+		return found[0], nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q matches %d %ss:\n", query, len(found), kind)
+	for _, item := range found {
+		fmt.Fprintf(&b, "  %d\t%s\n", id(item), name(item))
+	}
+	fmt.Fprintf(&b, "Pass the %s ID instead", kind)
+	return zero, &ambiguousError{b.String()}
+}
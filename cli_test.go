@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func resolveTestProject(projects []Project, query string, first bool) (Project, error) {
+	project, err := resolveByName(projects, query, first, "project",
+		func(p Project) int { return p.ID }, func(p Project) string { return p.Name })
+	return project, withFirstHint(err)
+}
+
+func TestResolveByName(t *testing.T) {
+	projects := []Project{
+		{ID: 30, Name: "Website"},
+		{ID: 10, Name: "Website Redesign"},
+		{ID: 20, Name: "Mobile App"},
+		{ID: 40, Name: "App Store"},
+		{ID: 2024, Name: "Budget 2024"},
+		{ID: 50, Name: "Website Redesign"},
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		first  bool
+		wantID int
+	}{
+		{"ID", "20", false, 20},
+		{"ID beats a name containing it", "2024", false, 2024},
+		{"exact name beats substring", "website", false, 30},
+		{"single substring", "mobile", false, 20},
+		{"first takes the first by name", "app", true, 40},
+		{"first breaks name ties by ID", "redesign", true, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveTestProject(projects, tt.query, tt.first)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.ID != tt.wantID {
+				t.Errorf("resolved %q to %d (%s), want %d", tt.query, got.ID, got.Name, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestResolveByNameAmbiguous(t *testing.T) {
+	projects := []Project{
+		{ID: 20, Name: "Mobile App"},
+		{ID: 40, Name: "App Store"},
+		{ID: 30, Name: "Website"},
+	}
+
+	_, err := resolveTestProject(projects, "app", false)
+	if err == nil {
+		t.Fatal("ambiguous name resolved without --first")
+	}
+	msg := err.Error()
+	for _, want := range []string{"40\tApp Store", "20\tMobile App", "--first"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error %q doesn't mention %q", msg, want)
+		}
+	}
+	if strings.Contains(msg, "Website") {
+		t.Errorf("error %q lists a project that doesn't match", msg)
+	}
+	if strings.Index(msg, "App Store") > strings.Index(msg, "Mobile App") {
+		t.Errorf("candidates not listed by name: %q", msg)
+	}
+}
+
+func TestResolveByNameNoMatch(t *testing.T) {
+	_, err := resolveTestProject([]Project{{ID: 1, Name: "Website"}}, "mobile", true)
+	if err == nil || !strings.Contains(err.Error(), `No project matching "mobile"`) {
+		t.Errorf("err = %v, want no match", err)
+	}
+}
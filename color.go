@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Values of the --color flag
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// Palette colors with explicit fallbacks for 256 and 16 color terminals, so
+// limited terminals get a chosen color instead of the nearest match to the
+// hex value
+var (
+	colorTitleFg = lipgloss.CompleteColor{TrueColor: "#FAFAFA", ANSI256: "255", ANSI: "15"}
+	colorTitleBg = lipgloss.CompleteColor{TrueColor: "#7D56F4", ANSI256: "99", ANSI: "5"}
+	colorInfo    = lipgloss.CompleteColor{TrueColor: "#888888", ANSI256: "245", ANSI: "8"}
+	colorError   = lipgloss.CompleteColor{TrueColor: "#FF0000", ANSI256: "196", ANSI: "9"}
+	colorSuccess = lipgloss.CompleteColor{TrueColor: "#00FF00", ANSI256: "46", ANSI: "10"}
+	colorWarning = lipgloss.CompleteColor{TrueColor: "#FFAA00", ANSI256: "214", ANSI: "11"}
+)
+
+// Pick the color profile for the given --color mode. Auto detects the
+// terminal from TERM and COLORTERM and honours NO_COLOR, always uses the
+// terminal's colors even when output isn't a terminal, and never disables
+// colors entirely.
+func colorProfile(mode string) (termenv.Profile, error) {
+	switch mode {
+	case "", colorAuto:
+		return termenv.NewOutput(os.Stdout).EnvColorProfile(), nil
+	case colorAlways:
+		profile := termenv.NewOutput(os.Stdout, termenv.WithUnsafe()).ColorProfile()
+		if profile == termenv.Ascii {
+			profile = termenv.ANSI
+		}
+		return profile, nil
+	case colorNever:
+		return termenv.Ascii, nil
+	default:
+		return termenv.Ascii, fmt.Errorf("--color must be %q, %q or %q", colorAuto, colorAlways, colorNever)
+	}
+}
+
+// Apply the color profile for the given --color mode to all styles
+func applyColorMode(mode string) error {
+	profile, err := colorProfile(mode)
+	if err != nil {
+		return err
+	}
+	lipgloss.SetColorProfile(profile)
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Render with a forced color profile, restoring the previous one afterwards
+func renderWithProfile(t *testing.T, profile termenv.Profile, style lipgloss.Style, s string) string {
+	t.Helper()
+	previous := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(profile)
+	t.Cleanup(func() { lipgloss.SetColorProfile(previous) })
+	return style.Render(s)
+}
+
+func TestStylesUseANSIFallbacks(t *testing.T) {
+	tests := []struct {
+		name  string
+		style lipgloss.Style
+		want  string
+	}{
+		{"error", errorStyle, "\x1b[91m"},     // bright red
+		{"success", successStyle, "\x1b[92m"}, // bright green
+		{"info", infoStyle, "\x1b[90m"},       // bright black
+		{"title", titleStyle, "97;45m"},       // bright white on magenta
+	}
+	for _, tt := range tests {
+		got := renderWithProfile(t, termenv.ANSI, tt.style, "text")
+		if !strings.Contains(got, tt.want) {
+			t.Errorf("%s style under 16 colors = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStylesWithoutColor(t *testing.T) {
+	got := renderWithProfile(t, termenv.Ascii, errorStyle, "text")
+	if strings.Contains(got, "\x1b[") {
+		t.Errorf("error style with colors off = %q, want no escape codes", got)
+	}
+}
+
+func TestColorProfile(t *testing.T) {
+	if profile, err := colorProfile(colorNever); err != nil || profile != termenv.Ascii {
+		t.Errorf("never = %v, %v, want Ascii", profile, err)
+	}
+	if profile, err := colorProfile(colorAlways); err != nil || profile == termenv.Ascii {
+		t.Errorf("always = %v, %v, want colors", profile, err)
+	}
+	if _, err := colorProfile("sometimes"); err == nil {
+		t.Error("unknown mode accepted")
+	}
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Default tick intervals. The elapsed display refreshes on coarse ticks to
+// save battery and interpolates locally from the timer's start time.
+const (
+	defaultTickInterval      = 30 * time.Second
+	preciseTickInterval      = time.Second
+	defaultReconcileInterval = 5 * time.Minute
+	defaultRefreshInterval   = 10 * time.Minute
+)
+
+// Page sizes for paginated API calls. Harvest accepts up to 2000 records per page.
+const (
+	defaultPerPage = 100
+	maxPerPage     = 2000
+)
+
+// Get the path of the configuration file
+func configPath() (string, error) {
+	if path := os.Getenv("HARVEST_TUI_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "harvest-tui", "config.json"), nil
+}
+
+// Load the configuration file, falling back to defaults when it doesn't exist
+func loadConfig() (Configuration, error) {
+	var config Configuration
+
+	path, err := configPath()
+	if err != nil {
+		return config, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+
+	if err := config.normalizeAliases(); err != nil {
+		return config, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+	if err := config.validate(); err != nil {
+		return config, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+
+	return config, nil
+}
+
+// Lowercase alias names written by hand in the config file, and the quick
+// actions naming them, since aliases are looked up in lowercase
+func (c *Configuration) normalizeAliases() error {
+	if c.Aliases != nil {
+		aliases := make(map[string]Alias, len(c.Aliases))
+		for name, alias := range c.Aliases {
+			lower := strings.ToLower(name)
+			if _, ok := aliases[lower]; ok {
+				return fmt.Errorf("aliases: %q is defined more than once with different case", lower)
+			}
+			aliases[lower] = alias
+		}
+		c.Aliases = aliases
+	}
+
+	for key, action := range c.QuickActions {
+		action.Alias = strings.ToLower(action.Alias)
+		c.QuickActions[key] = action
+	}
+	return nil
+}
+
+// Check configured values against the limits Harvest accepts
+func (c Configuration) validate() error {
+	if c.PerPage < 0 || c.PerPage > maxPerPage {
+		return fmt.Errorf("per_page must be between 1 and %d", maxPerPage)
+	}
+	if c.MaxIdleConns < 0 || c.MaxIdleConnsPerHost < 0 || c.IdleConnTimeoutSeconds < 0 || c.KeepAliveSeconds < 0 {
+		return fmt.Errorf("connection pool settings must be positive")
+	}
+	if c.BreakerThreshold < 0 || c.BreakerCooldownSeconds < 0 {
+		return fmt.Errorf("breaker_threshold and breaker_cooldown_seconds must be positive")
+	}
+	if c.MaxIdleConns > 0 && c.MaxIdleConnsPerHost > c.MaxIdleConns {
+		return fmt.Errorf("max_idle_conns_per_host must not exceed max_idle_conns")
+	}
+	if c.TickIntervalSeconds < 0 || c.ReconcileIntervalSeconds < 0 || c.RefreshIntervalSeconds < 0 || c.IdleStopMinutes < 0 || c.MinimumMinutes < 0 || c.SwitchGraceSeconds < 0 || c.FocusNudgeMinutes < 0 {
+		return fmt.Errorf("intervals must be positive")
+	}
+	switch c.Feedback {
+	case "", feedbackInline, feedbackBanner, feedbackFlash:
+	default:
+		return fmt.Errorf("feedback must be %q, %q or %q", feedbackInline, feedbackBanner, feedbackFlash)
+	}
+	if err := c.WorkHours.validate(); err != nil {
+		return err
+	}
+	keys, err := newKeyMap(c.Keys)
+	if err != nil {
+		return err
+	}
+	if err := validateQuickActions(c.QuickActions, c.Aliases, keys); err != nil {
+		return err
+	}
+	switch c.ListDensity {
+	case "", densityComfortable, densityCompact:
+	default:
+		return fmt.Errorf("list_density must be %q or %q", densityComfortable, densityCompact)
+	}
+	for projectID, estimate := range c.Estimates {
+		if estimate < 0 {
+			return fmt.Errorf("estimates: project %d has a negative estimate", projectID)
+		}
+	}
+	switch c.DeleteConfirm {
+	case "", deleteConfirmAlways, deleteConfirmLong, deleteConfirmNever:
+	default:
+		return fmt.Errorf("delete_confirm must be %q, %q or %q", deleteConfirmAlways, deleteConfirmLong, deleteConfirmNever)
+	}
+	if c.DeleteConfirmHours < 0 {
+		return fmt.Errorf("delete_confirm_hours must be positive")
+	}
+	if _, ok := weekdays[weekdayKey(c.WeekStart)]; c.WeekStart != "" && !ok {
+		return fmt.Errorf("week_start must be a day of the week, e.g. \"mon\" or \"sun\"")
+	}
+	if c.BaseURL != "" {
+		u, err := url.Parse(c.BaseURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("base_url must be an http(s) URL, e.g. %q", defaultBaseURL)
+		}
+	}
+	if c.APIVersion != "" && !apiVersionPattern.MatchString(c.APIVersion) {
+		return fmt.Errorf("api_version must look like %q", defaultAPIVersion)
+	}
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			return fmt.Errorf("timezone: %v", err)
+		}
+	}
+	if _, ok := listFilters[c.ListFilter]; c.ListFilter != "" && !ok {
+		return fmt.Errorf("list_filter must be %q or %q", filterRanked, filterFuzzy)
+	}
+	return nil
+}
+
+// Write the configuration file, creating its directory if needed
+func saveConfig(config Configuration) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// Interval between refreshes of the elapsed time display
+func (c Configuration) tickInterval() time.Duration {
+	if c.HighPrecision {
+		return preciseTickInterval
+	}
+	if c.TickIntervalSeconds > 0 {
+		return time.Duration(c.TickIntervalSeconds) * time.Second
+	}
+	return defaultTickInterval
+}
+
+// Separator used when appending to notes
+func (c Configuration) noteSeparator() string {
+	if c.NoteSeparator != "" {
+		return c.NoteSeparator
+	}
+	return defaultNoteSeparator
+}
+
+// Number of records to request per page
+func (c Configuration) perPage() int {
+	if c.PerPage > 0 {
+		return c.PerPage
+	}
+	return defaultPerPage
+}
+
+// Interval between running timer checks against the server
+func (c Configuration) reconcileInterval() time.Duration {
+	if c.ReconcileIntervalSeconds > 0 {
+		return time.Duration(c.ReconcileIntervalSeconds) * time.Second
+	}
+	return defaultReconcileInterval
+}
+
+// Interval between background refreshes of the project and task lists
+func (c Configuration) refreshInterval() time.Duration {
+	if c.RefreshIntervalSeconds > 0 {
+		return time.Duration(c.RefreshIntervalSeconds) * time.Second
+	}
+	return defaultRefreshInterval
+}
+
+// A version path segment such as "v2"
+var apiVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// Base URL for API requests: the host (and any gateway prefix) followed by
+// the version. A version already at the end of base_url is used unless
+// api_version overrides it, so ".../v2", ".../v2/" and "..." with
+// api_version "v2" all give the same URL.
+func (c Configuration) apiURL() string {
+	base := strings.TrimRight(c.BaseURL, "/")
+	if base == "" {
+		base = defaultBaseURL
+	}
+
+	version := defaultAPIVersion
+	if i := strings.LastIndex(base, "/"); i >= 0 && apiVersionPattern.MatchString(base[i+1:]) {
+		version = base[i+1:]
+		base = base[:i]
+	}
+	if c.APIVersion != "" {
+		version = c.APIVersion
+	}
+
+	return base + "/" + version
+}
+
+// First day of the week, Monday unless configured
+func (c Configuration) firstWeekday() time.Weekday {
+	if day, ok := weekdays[weekdayKey(c.WeekStart)]; ok {
+		return day
+	}
+	return time.Monday
+}
+
+// Key into weekdays for a day name, accepting "sun" and "Sunday" alike
+func weekdayKey(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) > 3 {
+		name = name[:3]
+	}
+	return name
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAPIURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    string
+		version string
+		want    string
+	}{
+		{"empty", "", "", "https://api.harvestapp.com/v2"},
+		{"no version", "https://api.harvestapp.com", "", "https://api.harvestapp.com/v2"},
+		{"trailing version", "https://api.harvestapp.com/v2", "", "https://api.harvestapp.com/v2"},
+		{"trailing version and slash", "https://api.harvestapp.com/v2/", "", "https://api.harvestapp.com/v2"},
+		{"other version", "https://api.harvestapp.com/v3", "", "https://api.harvestapp.com/v3"},
+		{"gateway prefix", "https://gateway.example.com/harvest", "", "https://gateway.example.com/harvest/v2"},
+		{"gateway prefix and version", "https://gateway.example.com/harvest/v2/", "", "https://gateway.example.com/harvest/v2"},
+		{"api_version only", "", "v3", "https://api.harvestapp.com/v3"},
+		{"api_version overrides trailing version", "https://api.harvestapp.com/v2", "v3", "https://api.harvestapp.com/v3"},
+		{"api_version with gateway", "https://gateway.example.com/harvest/v2", "v3", "https://gateway.example.com/harvest/v3"},
+	}
+	for _, tt := range tests {
+		got := Configuration{BaseURL: tt.base, APIVersion: tt.version}.apiURL()
+		if got != tt.want {
+			t.Errorf("%s: apiURL(%q, %q) = %q, want %q", tt.name, tt.base, tt.version, got, tt.want)
+		}
+	}
+}
+
+// Load a config file with the given contents
+func loadTestConfig(t *testing.T, contents string) (Configuration, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HARVEST_TUI_CONFIG", path)
+	return loadConfig()
+}
+
+func TestLoadConfigLowercasesAliases(t *testing.T) {
+	config, err := loadTestConfig(t, `{
+		"aliases": {"Deploy": {"project_id": 1, "task_id": 2}},
+		"quick_actions": {"f2": {"alias": "DEPLOY"}}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := config.Aliases["deploy"]; !ok || len(config.Aliases) != 1 {
+		t.Errorf("aliases = %+v, want deploy", config.Aliases)
+	}
+	if got := config.QuickActions["f2"].Alias; got != "deploy" {
+		t.Errorf("quick action alias = %q, want deploy", got)
+	}
+	if _, err := resolveTarget(nil, config, "Deploy", "", "", false); err != nil {
+		t.Errorf("alias Deploy not found: %v", err)
+	}
+}
+
+func TestLoadConfigRejectsAliasesDifferingInCase(t *testing.T) {
+	_, err := loadTestConfig(t, `{"aliases": {"deploy": {"project_id": 1}, "Deploy": {"project_id": 2}}}`)
+	if err == nil || !strings.Contains(err.Error(), `"deploy"`) {
+		t.Errorf("err = %v, want the duplicate alias named", err)
+	}
+}
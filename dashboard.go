@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Bars used to draw sparklines, from lowest to highest
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// One-line summary of the day shown under the title on the main screens
+func (m Model) dashboardView() string {
+	switch m.state {
+	case "select_project", "select_task", "enter_details":
+	default:
+		return ""
+	}
+
+	var parts []string
+
+	focus := "—"
+	if longest := m.longestFocus(); longest > 0 {
+		focus = formatElapsed(longest, false)
+	}
+	parts = append(parts, "Longest focus: "+focus)
+	parts = append(parts, m.lastTrackedLabel())
+
+	if days := m.trendHours(); days != nil {
+		var total float64
+		for _, hours := range days {
+			total += hours
+		}
+		parts = append(parts, fmt.Sprintf("7 days %s %.1fh", sparkline(days), total))
+	}
+
+	if earnings := m.earningsLabel(); earnings != "" {
+		parts = append(parts, earnings)
+	}
+
+	return infoStyle.Render(strings.Join(parts, " · ")) + "\n\n"
+}
+
+// Longest single entry tracked today. Each entry is one uninterrupted
+// stretch of a timer; the running timer contributes its live elapsed time.
+func (m Model) longestFocus() time.Duration {
+	today := time.Now().Format("2006-01-02")
+
+	var longest time.Duration
+	for _, entry := range m.todayEntries {
+		if entry.SpentDate != today {
+			continue
+		}
+		longest = max(longest, time.Duration(m.entryHours(entry)*float64(time.Hour)))
+	}
+
+	if m.activeTimer != nil {
+		longest = max(longest, m.elapsed())
+	}
+
+	return longest
+}
+
+// How long ago the most recent entry ended, "tracking now" while a timer
+// runs
+func (m Model) lastTrackedLabel() string {
+	if m.activeTimer != nil {
+		return "Tracking now"
+	}
+	if m.todayEntries == nil {
+		return "Last tracked —"
+	}
+
+	var last time.Time
+	for _, entry := range m.todayEntries {
+		if entry.IsRunning {
+			return "Tracking now"
+		}
+		if ended := entryEnd(entry); ended.After(last) {
+			last = ended
+		}
+	}
+	if last.IsZero() {
+		return "Nothing tracked yet today"
+	}
+
+	ago := max(time.Since(last), 0).Truncate(time.Minute)
+	if ago < time.Minute {
+		return "Last tracked just now"
+	}
+	return "Last tracked " + formatAgo(ago) + " ago"
+}
+
+// When an entry ended. Entries tracked with timestamps carry their end
+// time; otherwise the last update is the best guess, since stopping a
+// timer updates the entry.
+func entryEnd(entry TimeEntry) time.Time {
+	for _, layout := range []string{"15:04", "3:04pm"} {
+		ended, err := time.ParseInLocation("2006-01-02 "+layout, entry.SpentDate+" "+entry.EndedTime, time.Local)
+		if err == nil {
+			return ended
+		}
+	}
+	return entry.UpdatedAt
+}
+
+// Duration as "2h 10m", or "25m" under an hour
+func formatAgo(d time.Duration) string {
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// Date range and cache key of the last seven days, ending today
+func trendPeriod() (key, from, to string) {
+	today := time.Now()
+	from = today.AddDate(0, 0, -6).Format("2006-01-02")
+	to = today.Format("2006-01-02")
+	return "trend:" + from, from, to
+}
+
+// Sync the entries behind the seven day trend
+func (m Model) syncTrend() tea.Cmd {
+	key, from, to := trendPeriod()
+
+	var since time.Time
+	if cache, ok := m.entryCache[key]; ok {
+		since = cache.lastSync
+	}
+
+	return fetchEntries(m.harvestClient, key, from, to, since)
+}
+
+// Hours tracked on each of the last seven days, oldest first, or nil
+// until they have been fetched
+func (m Model) trendHours() []float64 {
+	key, from, _ := trendPeriod()
+	entries := m.cachedEntries(key)
+	if entries == nil {
+		return nil
+	}
+
+	start, _ := time.Parse("2006-01-02", from)
+	days := make([]float64, 7)
+	for _, entry := range entries {
+		date, err := time.Parse("2006-01-02", entry.SpentDate)
+		if err != nil {
+			continue
+		}
+		if i := int(date.Sub(start).Hours() / 24); i >= 0 && i < len(days) {
+			days[i] += m.entryHours(entry)
+		}
+	}
+
+	return days
+}
+
+// Render values as a unicode sparkline scaled to the largest value
+func sparkline(values []float64) string {
+	var highest float64
+	for _, v := range values {
+		highest = max(highest, v)
+	}
+
+	bars := make([]rune, len(values))
+	for i, v := range values {
+		level := 0
+		if highest > 0 {
+			level = int(v / highest * float64(len(sparkBars)-1))
+		}
+		bars[i] = sparkBars[level]
+	}
+
+	return string(bars)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// List densities
+const (
+	densityComfortable = "comfortable"
+	densityCompact     = "compact"
+)
+
+// itemDelegate renders project/task items with their details either on a
+// second line or, in compact mode, after the name on the same line
+type itemDelegate struct {
+	compact     bool
+	quickSelect bool
+	styles      list.DefaultItemStyles
+}
+
+func newItemDelegate(density string, quickSelect bool) itemDelegate {
+	return itemDelegate{
+		compact:     density == densityCompact,
+		quickSelect: quickSelect,
+		styles:      list.NewDefaultItemStyles(),
+	}
+}
+
+func (d itemDelegate) Height() int {
+	if d.compact {
+		return 1
+	}
+	return 2
+}
+
+func (d itemDelegate) Spacing() int {
+	if d.compact {
+		return 0
+	}
+	return 1
+}
+
+func (d itemDelegate) Update(tea.Msg, *list.Model) tea.Cmd { return nil }
+
+func (d itemDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	i, ok := item.(ListItem)
+	if !ok || m.Width() <= 0 {
+		return
+	}
+
+	var (
+		s           = &d.styles
+		isSelected  = index == m.Index()
+		emptyFilter = m.FilterState() == list.Filtering && m.FilterValue() == ""
+		isFiltered  = m.FilterState() == list.Filtering || m.FilterState() == list.FilterApplied
+	)
+
+	titleStyle, detailStyle := s.NormalTitle, s.NormalDesc
+	switch {
+	case emptyFilter:
+		titleStyle, detailStyle = s.DimmedTitle, s.DimmedDesc
+	case isSelected && m.FilterState() != list.Filtering:
+		titleStyle, detailStyle = s.SelectedTitle, s.SelectedDesc
+	}
+
+	// Number the first nine items for quick-select, except while typing a
+	// filter where digits go to the filter instead
+	prefix := ""
+	if d.quickSelect && m.FilterState() != list.Filtering {
+		prefix = "  "
+		if index < 9 {
+			prefix = fmt.Sprintf("%d ", index+1)
+		}
+	}
+
+	textWidth := m.Width() - titleStyle.GetPaddingLeft() - titleStyle.GetPaddingRight() - len(prefix)
+	title := ansi.Truncate(i.Name, textWidth, "…")
+
+	// Highlight the characters matched by the filter
+	if isFiltered && !emptyFilter {
+		unmatched := titleStyle.Inline(true)
+		matched := unmatched.Inherit(s.FilterMatch)
+		title = lipgloss.StyleRunes(title, m.MatchesForItem(index), matched, unmatched)
+	}
+
+	if d.compact {
+		line := title
+		if i.Detail != "" {
+			detailWidth := textWidth - lipgloss.Width(title) - 3
+			if detailWidth > 0 {
+				line += detailStyle.Inline(true).Render(" · " + ansi.Truncate(i.Detail, detailWidth, "…"))
+			}
+		}
+		fmt.Fprint(w, titleStyle.Render(prefix+line))
+		return
+	}
+
+	fmt.Fprintf(w, "%s\n%s",
+		titleStyle.Render(prefix+title),
+		detailStyle.Render(strings.Repeat(" ", len(prefix))+ansi.Truncate(i.Detail, textWidth, "…")))
+}
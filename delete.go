@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// When to confirm deleting an entry
+const (
+	deleteConfirmAlways = "always"
+	deleteConfirmLong   = "long"
+	deleteConfirmNever  = "never"
+)
+
+// An entry was deleted from the summary
+type entryDeletedMsg struct{ entry TimeEntry }
+
+// Whether deleting an entry of this many hours needs confirmation under
+// the configured policy
+func (c Configuration) confirmDelete(hours float64) bool {
+	switch c.DeleteConfirm {
+	case deleteConfirmNever:
+		return false
+	case deleteConfirmLong:
+		return hours > c.DeleteConfirmHours
+	}
+	return true
+}
+
+// Delete the summary entry under the cursor, asking first when configured
+func (m Model) deleteSelectedEntry() (tea.Model, tea.Cmd) {
+	entry, ok := m.selectedEntry()
+	if !ok || m.refuseInSafeMode() {
+		return m, nil
+	}
+
+	// Harvest rejects it anyway, but never even offer it
+	if entry.IsLocked || entry.IsBilled {
+		m.error = "Locked or invoiced entries can't be deleted"
+		return m, nil
+	}
+
+	m.error = ""
+	m.success = ""
+	m.deleteEntry = entry
+	if m.config.confirmDelete(m.entryHours(entry)) {
+		m.deleteReturn = m.state
+		m.state = "confirm_delete"
+		return m, nil
+	}
+	return m, deleteEntry(m.harvestClient, entry)
+}
+
+// Handle the delete confirmation prompt
+func (m Model) updateDeletePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y":
+		m.state = m.deleteReturn
+		return m, deleteEntry(m.harvestClient, m.deleteEntry)
+	case "n", "esc":
+		m.state = m.deleteReturn
+	}
+	return m, nil
+}
+
+// Command to delete an entry
+func deleteEntry(client *HarvestClient, entry TimeEntry) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.DeleteTimeEntry(entry.ID); err != nil {
+			return errorMsg{error: err.Error()}
+		}
+		return entryDeletedMsg{entry: entry}
+	}
+}
+
+// Drop a deleted entry from the caches, which delta syncs can't do
+func (m Model) handleEntryDeleted(msg entryDeletedMsg) (tea.Model, tea.Cmd) {
+	for _, cache := range m.entryCache {
+		delete(cache.entries, msg.entry.ID)
+	}
+	if cache, ok := m.projectHours[msg.entry.Project.ID]; ok {
+		delete(cache.entries, msg.entry.ID)
+	}
+	if key, _, _ := m.summaryPeriod(); m.summaryEntries != nil {
+		m.summaryEntries = m.summaryEntriesFor(key)
+		m.summaryCursor = min(m.summaryCursor, max(len(m.summaryEntries)-1, 0))
+	}
+
+	if m.activeTimer != nil && m.activeTimer.ID == msg.entry.ID {
+		m.clearTimer()
+	}
+
+	m.success = fmt.Sprintf("Deleted %.2fh on %s / %s", msg.entry.Hours, msg.entry.Project.Name, msg.entry.Task.Name)
+	return m, m.refreshToday()
+}
+
+// Prompt naming the entry about to be deleted
+func (m Model) deletePrompt() string {
+	entry := m.deleteEntry
+	notes := entry.Notes
+	if notes == "" {
+		notes = "(no notes)"
+	}
+	return fmt.Sprintf("Delete this entry from Harvest?\n\n  %s · %.2fh · %s / %s\n  %s\n\n"+
+		"This can't be undone.\n\ny = delete, n = keep it",
+		entry.SpentDate, m.entryHours(entry), entry.Project.Name, entry.Task.Name, notes)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Check the configuration, credentials and time zone, offering to fix what
+// can be fixed from here
+func runDoctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	adopt := fs.Bool("adopt-timezone", false, "use the Harvest time zone without asking")
+	diagnostics := fs.Bool("diagnostics", false, "show API latency per endpoint")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	config, err := commandConfig()
+	if err != nil {
+		fmt.Printf("✗ config: %v\n", err)
+		return 1
+	}
+	fmt.Println("✓ config file and credentials found")
+
+	client := NewHarvestClient(config)
+	if config.diagnostics() || *diagnostics {
+		defer printLatency(client.stats)
+	}
+	if err := client.TestConnection(); err != nil {
+		fmt.Printf("✗ connection: %v\n", err)
+		return 1
+	}
+	fmt.Println("✓ connected to Harvest")
+
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		fmt.Printf("✗ time zone: %v\n", err)
+		return 1
+	}
+	zone, mismatch, err := timezoneMismatch(user.Timezone)
+	if err != nil {
+		fmt.Printf("? time zone: %v, not compared\n", err)
+		return 0
+	}
+	if mismatch == "" {
+		fmt.Printf("✓ time zone matches Harvest (%s)\n", zone)
+		return 0
+	}
+	fmt.Printf("! %s\n", mismatch)
+
+	if !*adopt {
+		if !stdinIsTerminal() {
+			fmt.Println("  Run harvest-tui doctor --adopt-timezone to use the Harvest time zone")
+			return 0
+		}
+		fmt.Printf("  Use %s as the time zone for harvest-tui? [y/N] ", zone)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if !strings.EqualFold(strings.TrimSpace(answer), "y") {
+			return 0
+		}
+	}
+
+	config.Timezone = zone
+	if err := saveConfig(config); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("✓ time zone set to %s in the config file\n", zone)
+	return 0
+}
+
+// Print the latency of the endpoints the checks called
+func printLatency(stats *requestStats) {
+	lines := stats.latencyLines(0)
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Println("\nAPI latency:")
+	for _, line := range lines {
+		fmt.Println("  " + line)
+	}
+}
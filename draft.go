@@ -0,0 +1,65 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// How long typing has to pause before the notes draft is written
+const draftSaveDelay = 2 * time.Second
+
+// draft is the notes being typed for a project/task, kept on disk so they
+// survive a crash
+type draft struct {
+	ProjectID int    `json:"project_id"`
+	TaskID    int    `json:"task_id"`
+	Notes     string `json:"notes"`
+}
+
+// Message to write the draft once typing has paused
+type draftSaveMsg struct{ id int }
+
+// Load the saved draft, ignoring a missing or corrupt file
+func loadDraft() (draft, bool) {
+	var d draft
+	if !draftFile.load(&d) || d.Notes == "" {
+		return d, false
+	}
+	return d, true
+}
+
+// Command to write the draft, or remove it when the notes are empty
+func saveDraft(d draft) tea.Cmd {
+	if d.Notes == "" {
+		return draftFile.remove()
+	}
+	return draftFile.save(d)
+}
+
+// Schedule a draft write after typing pauses. Each keystroke replaces the
+// previous schedule, so the file is written once per pause.
+func (m *Model) scheduleDraftSave() tea.Cmd {
+	if m.config.DisableDrafts {
+		return nil
+	}
+
+	m.draftID++
+	id := m.draftID
+	return tea.Tick(draftSaveDelay, func(time.Time) tea.Msg {
+		return draftSaveMsg{id: id}
+	})
+}
+
+// Restore the saved draft when returning to the same project/task
+func (m *Model) restoreDraft() {
+	if m.config.DisableDrafts || m.activeTimer != nil || m.ticketInput.Value() != "" {
+		return
+	}
+
+	d, ok := loadDraft()
+	if ok && d.ProjectID == m.selectedProject.ID && d.TaskID == m.selectedTask.ID {
+		m.ticketInput.SetValue(d.Notes)
+		m.success = "Restored unsaved notes"
+	}
+}
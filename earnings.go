@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Earnings is the billable amount of a set of entries. Billable entries
+// without a rate are left out of the amount and counted instead.
+type Earnings struct {
+	Amount  float64 `json:"amount"`
+	Unrated int     `json:"unrated_entries"`
+}
+
+// Sum billable hours × rate. Harvest resolves each entry's rate from the
+// task assignment, project or person, so rates can differ per entry. The
+// second result is false when no entry has a rate at all.
+func sumEarnings(entries []TimeEntry, hours func(TimeEntry) float64) (Earnings, bool) {
+	var earnings Earnings
+	rated := false
+	for _, entry := range entries {
+		if !entry.Billable {
+			continue
+		}
+		if entry.BillableRate == nil || *entry.BillableRate <= 0 {
+			earnings.Unrated++
+			continue
+		}
+		rated = true
+		earnings.Amount += hours(entry) * *entry.BillableRate
+	}
+	return earnings, rated
+}
+
+// Amount with thousands separators and the configured currency: a symbol
+// goes in front ("€1,234.50"), a code after ("1,234.50 EUR")
+func formatAmount(amount float64, currency string) string {
+	s := fmt.Sprintf("%.2f", amount)
+	whole, cents, _ := strings.Cut(s, ".")
+
+	sign := ""
+	if strings.HasPrefix(whole, "-") {
+		sign, whole = "-", whole[1:]
+	}
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+	s = sign + whole + "." + cents
+
+	switch {
+	case currency == "":
+		return s
+	case strings.IndexFunc(currency, unicode.IsLetter) < 0:
+		return currency + s
+	}
+	return s + " " + currency
+}
+
+// Amount and the entries left out, e.g. "€1,234.50 (2 entries without a rate)"
+func (e Earnings) label(currency string) string {
+	s := formatAmount(e.Amount, currency)
+	switch e.Unrated {
+	case 0:
+		return s
+	case 1:
+		return s + " (1 entry without a rate)"
+	}
+	return fmt.Sprintf("%s (%d entries without a rate)", s, e.Unrated)
+}
+
+// Date range and cache key of the current month so far
+func monthPeriod() (key, from, to string) {
+	today := time.Now()
+	from = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.Local).Format("2006-01-02")
+	to = today.Format("2006-01-02")
+	return "month:" + from, from, to
+}
+
+// Sync the entries behind the monthly earnings, when they are shown
+func (m Model) syncMonth() tea.Cmd {
+	if !m.config.ShowEarnings {
+		return nil
+	}
+
+	key, from, to := monthPeriod()
+	var since time.Time
+	if cache, ok := m.entryCache[key]; ok {
+		since = cache.lastSync
+	}
+	return fetchEntries(m.harvestClient, key, from, to, since)
+}
+
+// Dashboard part with the month's billable amount, empty until the entries
+// are fetched or when none has a rate
+func (m Model) earningsLabel() string {
+	if !m.config.ShowEarnings {
+		return ""
+	}
+
+	key, _, _ := monthPeriod()
+	entries := m.cachedEntries(key)
+	if entries == nil {
+		return ""
+	}
+	earnings, rated := sumEarnings(entries, m.entryHours)
+	if !rated {
+		return ""
+	}
+	return "Month " + earnings.label(m.config.Currency)
+}
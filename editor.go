@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Result of editing the notes in an external editor
+type editorDoneMsg struct {
+	notes string
+	err   error
+}
+
+// Editor command from $VISUAL or $EDITOR, which may carry arguments such
+// as "code --wait", like git uses them
+func externalEditor() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return nil
+}
+
+// Suspend the TUI and edit the notes in the external editor, reading them
+// back once it exits
+func (m Model) openEditor() (tea.Model, tea.Cmd) {
+	editor := externalEditor()
+	if editor == nil {
+		m.error = "Set $EDITOR to write notes in an editor; keep typing here instead"
+		return m, nil
+	}
+
+	file, err := os.CreateTemp("", "harvest-notes-*.txt")
+	if err != nil {
+		m.error = fmt.Sprintf("Failed to open the editor: %v", err)
+		return m, nil
+	}
+	path := file.Name()
+	_, err = file.WriteString(m.notesText())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		m.error = fmt.Sprintf("Failed to open the editor: %v", err)
+		return m, nil
+	}
+
+	m.error = ""
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return editorDoneMsg{err: err}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return editorDoneMsg{err: err}
+		}
+		return editorDoneMsg{notes: strings.TrimRight(string(data), "\r\n")}
+	})
+}
+
+// Put the edited notes in the notes field. A failed editor, such as one
+// exiting non-zero, leaves the notes as they were. The field is a single
+// line, so notes with line breaks are kept aside and sent as written.
+func (m Model) handleEditorDone(msg editorDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.error = fmt.Sprintf("Editor failed, notes unchanged: %v", msg.err)
+		return m, nil
+	}
+
+	m.ticketInput.SetValue(msg.notes)
+	m.ticketInput.CursorEnd()
+	m.ticketInput.Focus()
+	m.editedNotes, m.editedShown = msg.notes, m.ticketInput.Value()
+	m.historyCursor = 0
+
+	if len([]rune(strings.TrimSpace(msg.notes))) > maxNotesLength {
+		m.error = fmt.Sprintf("Notes are longer than Harvest's %d characters; the rest will be cut off", maxNotesLength)
+	} else if strings.Contains(msg.notes, "\n") {
+		m.success = "Line breaks are shown as spaces here but kept in the notes"
+	}
+
+	cmd := m.scheduleDraftSave()
+	return m, cmd
+}
+
+// Notes as entered: the editor's text, line breaks included, while the
+// notes field still shows it unedited, otherwise the field's value
+func (m Model) notesText() string {
+	if m.editedNotes != "" && m.ticketInput.Value() == m.editedShown {
+		return m.editedNotes
+	}
+	return m.ticketInput.Value()
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditorKeepsLineBreaks(t *testing.T) {
+	m := newTestModel(t)
+	notes := "TICKET-1 - Release\n\n- migrate\n- deploy"
+
+	model, _ := m.handleEditorDone(editorDoneMsg{notes: notes})
+	got := model.(Model)
+	if got.ticketInput.Value() != "TICKET-1 - Release  - migrate - deploy" {
+		t.Errorf("field shows %q", got.ticketInput.Value())
+	}
+	if got.notesText() != notes {
+		t.Errorf("notes = %q, want the line breaks kept", got.notesText())
+	}
+	if got.success == "" {
+		t.Error("no mention of the kept line breaks")
+	}
+
+	// Typing in the field afterwards replaces the edited notes
+	got.ticketInput.SetValue("TICKET-1 - Release")
+	if got.notesText() != "TICKET-1 - Release" {
+		t.Errorf("notes = %q after typing, want the field's value", got.notesText())
+	}
+}
+
+func TestEditorWarnsAboutLongNotes(t *testing.T) {
+	m := newTestModel(t)
+
+	model, _ := m.handleEditorDone(editorDoneMsg{notes: strings.Repeat("a", maxNotesLength+1)})
+	if got := model.(Model); !strings.Contains(got.error, "cut off") {
+		t.Errorf("error = %q, want a warning about the cut", got.error)
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/go-resty/resty/v2"
+)
+
+// Number of entries shown in the today panel
+const todayPanelEntries = 5
+
+// Tries per page of time entries
+const entryPageAttempts = 2
+
+// TimeEntry represents a logged Harvest time entry
+type TimeEntry struct {
+	ID        int     `json:"id"`
+	SpentDate string  `json:"spent_date"`
+	Hours     float64 `json:"hours"`
+	Notes     string  `json:"notes"`
+	IsRunning bool    `json:"is_running"`
+	Billable  bool    `json:"billable"`
+	IsLocked  bool    `json:"is_locked"`
+	IsBilled  bool    `json:"is_billed"`
+	Project   Project `json:"project"`
+	Task      Task    `json:"task"`
+
+	// Clock time the timer stopped, in the account's time format ("17:30"
+	// or "5:30pm"); empty for entries tracked as durations
+	EndedTime string    `json:"ended_time"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Hours after the account's rounding, null when the account doesn't
+	// round
+	RoundedHours *float64 `json:"rounded_hours"`
+
+	// Hourly rate of billable entries, null when no rate applies
+	BillableRate *float64 `json:"billable_rate"`
+
+	ExternalReference *ExternalReference `json:"external_reference"`
+
+	// Set once the entry has been billed
+	Invoice *struct {
+		ID     int    `json:"id"`
+		Number string `json:"number"`
+	} `json:"invoice"`
+}
+
+// Fetch all time entries between two dates (YYYY-MM-DD, inclusive), only
+// the client's user's when it has one. When updatedSince is set, only
+// entries created or changed after it are returned.
+func (h *HarvestClient) GetTimeEntries(from, to string, updatedSince time.Time) ([]TimeEntry, error) {
+	var entries []TimeEntry
+
+	query := fmt.Sprintf("from=%s&to=%s", from, to)
+	if h.userID != 0 {
+		query += fmt.Sprintf("&user_id=%d", h.userID)
+	}
+	if !updatedSince.IsZero() {
+		query += "&updated_since=" + url.QueryEscape(updatedSince.UTC().Format(time.RFC3339))
+	}
+
+	for page := 1; ; page++ {
+		var result struct {
+			TimeEntries []TimeEntry `json:"time_entries"`
+			NextPage    *int        `json:"next_page"`
+		}
+
+		// A page failing on the network or with a server error is retried
+		// before giving up on the whole range
+		var err error
+		for attempt := 0; attempt < entryPageAttempts; attempt++ {
+			var resp *resty.Response
+			resp, err = h.client.R().
+				SetResult(&result).
+				Get(fmt.Sprintf("/time_entries?%s&page=%d&per_page=%d", query, page, h.config.perPage()))
+			if err == nil && resp.IsError() {
+				err = fmt.Errorf("API error: Status=%d, Body=%s", resp.StatusCode(), resp.String())
+			}
+			if err == nil || !retryablePage(resp, err) {
+				break
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, result.TimeEntries...)
+		if result.NextPage == nil {
+			return entries, nil
+		}
+	}
+}
+
+// Whether a failed page request is worth repeating: network errors and 5xx
+// responses are, while 4xx responses, undecodable bodies and requests
+// refused by the circuit breaker would fail the same way again
+func retryablePage(resp *resty.Response, err error) bool {
+	var open *circuitOpenError
+	if errors.As(err, &open) {
+		return false
+	}
+	if resp == nil || resp.RawResponse == nil {
+		return true
+	}
+	return resp.StatusCode() >= http.StatusInternalServerError
+}
+
+// Fetch a single time entry, including its project and task names
+func (h *HarvestClient) GetTimeEntry(id int) (*TimeEntry, error) {
+	resp, err := h.client.R().
+		SetResult(&TimeEntry{}).
+		Get(fmt.Sprintf("/time_entries/%d", id))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("API error: Status=%d, Body=%s", resp.StatusCode(), resp.String())
+	}
+
+	return resp.Result().(*TimeEntry), nil
+}
+
+// Update fields of a time entry, e.g. {"notes": "..."}
+func (h *HarvestClient) UpdateTimeEntry(id int, fields map[string]any) (*TimeEntry, error) {
+	resp, err := h.client.R().
+		SetBody(fields).
+		SetResult(&TimeEntry{}).
+		Patch(fmt.Sprintf("/time_entries/%d", id))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("API error: Status=%d, Body=%s", resp.StatusCode(), resp.String())
+	}
+
+	return resp.Result().(*TimeEntry), nil
+}
+
+// Delete a time entry
+func (h *HarvestClient) DeleteTimeEntry(id int) error {
+	resp, err := h.client.R().
+		Delete(fmt.Sprintf("/time_entries/%d", id))
+	if err != nil {
+		return err
+	}
+
+	// Already gone is as good as deleted
+	if resp.IsError() && resp.StatusCode() != http.StatusNotFound {
+		return fmt.Errorf("API error: Status=%d, Body=%s", resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// Command to fetch today's entries
+func fetchTodayEntries(client *HarvestClient) tea.Cmd {
+	return func() tea.Msg {
+		today := time.Now().Format("2006-01-02")
+		entries, err := client.GetTimeEntries(today, today, time.Time{})
+		if err != nil {
+			return errorMsg{error: err.Error()}
+		}
+		return todayEntriesMsg{entries: entries}
+	}
+}
+
+// Refetch today's entries, the week trend and the month's earnings, used by
+// the today panel and the dashboard. Only called on startup and when
+// entries change, not on every tick.
+func (m Model) refreshToday() tea.Cmd {
+	return tea.Batch(fetchTodayEntries(m.harvestClient), m.syncTrend(), m.syncMonth())
+}
+
+// Hours of an entry, using the live elapsed time for the active timer
+func (m Model) entryHours(entry TimeEntry) float64 {
+	if m.activeTimer != nil && entry.ID == m.activeTimer.ID {
+		return m.elapsed().Hours()
+	}
+	return entry.Hours
+}
+
+// Number of lines the today panel occupies
+func (m Model) todayPanelHeight() int {
+	if !m.showToday {
+		return 0
+	}
+	return 3 + min(len(m.todayEntries), todayPanelEntries)
+}
+
+// Compact panel with the most recent of today's entries
+func (m Model) todayPanelView() string {
+	if !m.showToday {
+		return ""
+	}
+
+	if m.todayEntries == nil {
+		return "\n\n" + infoStyle.Render("Today: loading...")
+	}
+
+	var total float64
+	for _, entry := range m.todayEntries {
+		total += m.entryHours(entry)
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\n" + infoStyle.Render(fmt.Sprintf("Today · %s · %d entries (t to hide)",
+		formatElapsed(time.Duration(total*float64(time.Hour)), false), len(m.todayEntries))))
+
+	// Harvest returns the newest entries first
+	for _, entry := range m.todayEntries[:min(len(m.todayEntries), todayPanelEntries)] {
+		marker := " "
+		if m.activeTimer != nil && entry.ID == m.activeTimer.ID {
+			marker = "▶"
+		}
+
+		line := fmt.Sprintf("%s %5.2fh  %s / %s", marker, m.entryHours(entry), entry.Project.Name, entry.Task.Name)
+		if entry.Notes != "" {
+			line += " — " + entry.Notes
+		}
+		if m.width > 0 {
+			line = ansi.Truncate(line, m.width-docStyle.GetHorizontalFrameSize(), "…")
+		}
+		b.WriteString("\n" + line)
+	}
+
+	return b.String()
+}
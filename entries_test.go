@@ -0,0 +1,103 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetTimeEntriesRetries(t *testing.T) {
+	tests := []struct {
+		name    string
+		respond func(w http.ResponseWriter)
+		want    int32
+	}{
+		{"server error", func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) }, entryPageAttempts},
+		{"dropped connection", func(w http.ResponseWriter) {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+		}, entryPageAttempts},
+		{"client error", func(w http.ResponseWriter) { w.WriteHeader(http.StatusUnprocessableEntity) }, 1},
+		{"undecodable body", func(w http.ResponseWriter) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"time_entries": [`))
+		}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				tt.respond(w)
+			}))
+			defer server.Close()
+
+			client := NewHarvestClient(Configuration{BaseURL: server.URL + "/v2"})
+			if _, err := client.GetTimeEntries("2025-03-10", "2025-03-16", time.Time{}); err == nil {
+				t.Fatal("no error")
+			}
+			if got := requests.Load(); got != tt.want {
+				t.Errorf("%d requests, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTimeEntriesRecoversOnRetry(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"time_entries": [{"id": 1, "hours": 2}], "next_page": null}`))
+	}))
+	defer server.Close()
+
+	client := NewHarvestClient(Configuration{BaseURL: server.URL + "/v2"})
+	entries, err := client.GetTimeEntries("2025-03-10", "2025-03-16", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].ID != 1 {
+		t.Errorf("entries = %+v, want entry 1 from the retry", entries)
+	}
+}
+
+func TestEntriesOnlyTheUsers(t *testing.T) {
+	var mu sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"time_entries": []}`))
+	}))
+	defer server.Close()
+
+	client := NewHarvestClient(Configuration{BaseURL: server.URL + "/v2"})
+	client.userID = 42
+	if _, err := client.GetTimeEntries("2025-03-10", "2025-03-16", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetRunningTimer(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(queries) != 2 {
+		t.Fatalf("%d requests, want 2", len(queries))
+	}
+	for _, query := range queries {
+		if !strings.Contains(query, "user_id=42") {
+			t.Errorf("query %s isn't limited to the user", query)
+		}
+	}
+}
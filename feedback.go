@@ -0,0 +1,68 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Feedback intensities for successful timer actions
+const (
+	feedbackInline = "inline"
+	feedbackBanner = "banner"
+	feedbackFlash  = "flash"
+)
+
+const (
+	bannerDuration = 2 * time.Second
+	flashDuration  = 150 * time.Millisecond
+)
+
+type (
+	clearBannerMsg struct{ id int }
+	endFlashMsg    struct{ id int }
+)
+
+// Show a success banner (and optionally a flash) for a timer action,
+// depending on the configured feedback intensity
+func (m *Model) celebrate(text string) tea.Cmd {
+	switch m.config.Feedback {
+	case feedbackBanner, feedbackFlash:
+	default:
+		return nil
+	}
+
+	m.bannerID++
+	m.banner = text
+	id := m.bannerID
+
+	cmds := []tea.Cmd{tea.Tick(bannerDuration, func(time.Time) tea.Msg {
+		return clearBannerMsg{id: id}
+	})}
+
+	if m.config.Feedback == feedbackFlash && !m.config.ReduceMotion {
+		m.flashing = true
+		cmds = append(cmds, tea.Tick(flashDuration, func(time.Time) tea.Msg {
+			return endFlashMsg{id: id}
+		}))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// Full-width banner rendered under the title while active
+func (m Model) bannerView() string {
+	if m.banner == "" {
+		return ""
+	}
+
+	style := successStyle.Bold(true)
+	if m.flashing {
+		style = style.Reverse(true)
+	}
+	if m.width > 0 {
+		style = style.Width(m.width - docStyle.GetHorizontalFrameSize())
+	}
+
+	return style.Render("✓ "+m.banner) + "\n\n"
+}
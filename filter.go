@@ -0,0 +1,128 @@
+package main
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/sahilm/fuzzy"
+)
+
+// List filter matchers
+const (
+	filterRanked = "ranked"
+	filterFuzzy  = "fuzzy"
+)
+
+// Matchers available for the project and task list filters. Each is a
+// list.FilterFunc, so another one can be added here and picked in the config.
+var listFilters = map[string]list.FilterFunc{
+	filterRanked: rankedFilter,
+	filterFuzzy:  list.DefaultFilter,
+}
+
+// The configured list filter, defaulting to the ranked matcher
+func listFilter(name string) list.FilterFunc {
+	if filter, ok := listFilters[name]; ok {
+		return filter
+	}
+	return rankedFilter
+}
+
+// Match quality tiers, best first
+const (
+	matchPrefix    = iota // the name starts with the term
+	matchWordStart        // a word in the name starts with the term
+	matchSubstring        // the term appears inside a word
+	matchScattered        // the term's characters appear in order
+)
+
+// rankedFilter ranks names that start with the term first, then names with
+// a word starting with it, then plain substrings and finally scattered
+// fuzzy matches. Within a tier the sahilm/fuzzy score decides, so "dev"
+// puts "Dev Ops" before "Development" and both before "Data Evaluation".
+func rankedFilter(term string, targets []string) []list.Rank {
+	type ranked struct {
+		list.Rank
+		tier  int
+		score int
+	}
+
+	var results []ranked
+	for _, match := range fuzzy.Find(term, targets) {
+		r := ranked{
+			Rank:  list.Rank{Index: match.Index, MatchedIndexes: runeIndexes(match.Str, match.MatchedIndexes)},
+			tier:  matchScattered,
+			score: match.Score,
+		}
+
+		// Highlight the contiguous match rather than the fuzzy one
+		if tier, start, ok := substringMatch(match.Str, term); ok {
+			r.tier = tier
+			r.MatchedIndexes = r.MatchedIndexes[:0]
+			for i := range utf8.RuneCountInString(term) {
+				r.MatchedIndexes = append(r.MatchedIndexes, start+i)
+			}
+		}
+		results = append(results, r)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].tier != results[j].tier {
+			return results[i].tier < results[j].tier
+		}
+		return results[i].score > results[j].score
+	})
+
+	ranks := make([]list.Rank, len(results))
+	for i, r := range results {
+		ranks[i] = r.Rank
+	}
+	return ranks
+}
+
+// Find the term in the name ignoring case, preferring an occurrence at the
+// start of a word. Returns the tier and the rune offset of the match.
+func substringMatch(name, term string) (int, int, bool) {
+	haystack, needle := foldRunes(name), foldRunes(term)
+
+	found := -1
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		if string(haystack[i:i+len(needle)]) != string(needle) {
+			continue
+		}
+		switch {
+		case i == 0:
+			return matchPrefix, i, true
+		case !unicode.IsLetter(haystack[i-1]) && !unicode.IsDigit(haystack[i-1]):
+			return matchWordStart, i, true
+		case found < 0:
+			found = i
+		}
+	}
+
+	if found < 0 {
+		return 0, 0, false
+	}
+	return matchSubstring, found, true
+}
+
+// Lower-case a string rune by rune, keeping rune offsets aligned
+func foldRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// Convert the byte offsets reported by sahilm/fuzzy to the rune offsets
+// used for highlighting
+func runeIndexes(s string, byteIndexes []int) []int {
+	indexes := make([]int, len(byteIndexes))
+	for i, b := range byteIndexes {
+		indexes[i] = utf8.RuneCountInString(s[:b])
+	}
+	return indexes
+}
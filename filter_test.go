@@ -0,0 +1,77 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// Names matched by rankedFilter, best first
+func rankedNames(term string, targets []string) []string {
+	var names []string
+	for _, rank := range rankedFilter(term, targets) {
+		names = append(names, targets[rank.Index])
+	}
+	return names
+}
+
+func TestRankedFilterOrder(t *testing.T) {
+	targets := []string{
+		"Data Evaluation",
+		"Development",
+		"Web Development",
+		"Dev Ops",
+		"Mobile App",
+		"Apps Review",
+		"Happy Path Testing",
+		"Internal Admin",
+	}
+
+	tests := []struct {
+		term string
+		want []string
+	}{
+		// Prefix, then word start, then scattered
+		{"dev", []string{"Dev Ops", "Development", "Web Development", "Data Evaluation"}},
+		// Word start beats a substring inside a word
+		{"app", []string{"Apps Review", "Mobile App", "Happy Path Testing"}},
+		// Case is ignored
+		{"ADMIN", []string{"Internal Admin"}},
+		{"zzz", nil},
+	}
+	for _, tt := range tests {
+		if got := rankedNames(tt.term, targets); !slices.Equal(got, tt.want) {
+			t.Errorf("%q ranked %q, want %q", tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestRankedFilterHighlightsContiguousMatch(t *testing.T) {
+	ranks := rankedFilter("dev", []string{"Web Development"})
+	if len(ranks) != 1 {
+		t.Fatalf("%d matches, want 1", len(ranks))
+	}
+	if want := []int{4, 5, 6}; !slices.Equal(ranks[0].MatchedIndexes, want) {
+		t.Errorf("highlighted %v, want %v", ranks[0].MatchedIndexes, want)
+	}
+}
+
+func TestRankedFilterRuneIndexes(t *testing.T) {
+	ranks := rankedFilter("caf", []string{"Résumé Café"})
+	if len(ranks) != 1 {
+		t.Fatalf("%d matches, want 1", len(ranks))
+	}
+	// Runes, not bytes, so the accented letters don't shift the highlight
+	if want := []int{7, 8, 9}; !slices.Equal(ranks[0].MatchedIndexes, want) {
+		t.Errorf("highlighted %v, want %v", ranks[0].MatchedIndexes, want)
+	}
+}
+
+func TestListFilterDefaultsToRanked(t *testing.T) {
+	targets := []string{"Data Evaluation", "Dev Ops"}
+	for _, name := range []string{"", filterRanked, "unknown"} {
+		ranks := listFilter(name)("dev", targets)
+		if len(ranks) != 2 || targets[ranks[0].Index] != "Dev Ops" {
+			t.Errorf("filter %q ranked %+v, want Dev Ops first", name, ranks)
+		}
+	}
+}
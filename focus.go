@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Block glyphs for the focus clock, five rows each
+var clockGlyphs = map[rune][]string{
+	'0': {"███", "█ █", "█ █", "█ █", "███"},
+	'1': {" ██", "  █", "  █", "  █", "  █"},
+	'2': {"███", "  █", "███", "█  ", "███"},
+	'3': {"███", "  █", "███", "  █", "███"},
+	'4': {"█ █", "█ █", "███", "  █", "  █"},
+	'5': {"███", "█  ", "███", "  █", "███"},
+	'6': {"███", "█  ", "███", "█ █", "███"},
+	'7': {"███", "  █", "  █", "  █", "  █"},
+	'8': {"███", "█ █", "███", "█ █", "███"},
+	'9': {"███", "█ █", "███", "  █", "███"},
+	':': {" ", "█", " ", "█", " "},
+}
+
+// Handle keys while the focus overlay is shown: s stops the timer, any
+// other key returns to the normal screen
+func (m Model) updateFocusOverlay(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.focusMode = false
+	if msg.String() == "s" && m.activeTimer != nil {
+		return m.stopOrFinalize()
+	}
+	return m, nil
+}
+
+// Elapsed time drawn in block digits, H:MM or H:MM:SS in high precision
+func bigClock(text string) string {
+	rows := make([]string, 5)
+	for i, r := range text {
+		glyph, ok := clockGlyphs[r]
+		if !ok {
+			continue
+		}
+		for row := range rows {
+			if i > 0 {
+				rows[row] += " "
+			}
+			rows[row] += glyph[row]
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// Full-screen view with only the running timer, centered
+func (m Model) focusView() string {
+	d := m.elapsed()
+	clock := fmt.Sprintf("%d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	if m.config.HighPrecision {
+		clock += fmt.Sprintf(":%02d", int(d.Seconds())%60)
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		successStyle.Render(bigClock(clock)),
+		"",
+		timerLabel(m.activeTimer),
+		infoStyle.Render(m.activeTimer.Notes),
+		"",
+		infoStyle.Render("s to stop · any other key to return"),
+	)
+
+	if m.width == 0 || m.height == 0 {
+		return content
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -14,70 +19,273 @@ import (
 
 // Constants and styles
 const (
-	defaultBaseURL = "https://api.harvestapp.com/v2"
+	defaultBaseURL    = "https://api.harvestapp.com"
+	defaultAPIVersion = "v2"
+	maxNotesLength    = 1000
+
+	defaultNoteSeparator = " — "
+
+	// Smallest terminal the layout renders in without overlapping
+	minWidth  = 30
+	minHeight = 10
 )
 
 var (
 	titleStyle = lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#7D56F4")).
+			Foreground(colorTitleFg).
+			Background(colorTitleBg).
 			Padding(0, 1)
 
 	infoStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#888888"))
+			Foreground(colorInfo)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF0000"))
+			Foreground(colorError)
 
 	successStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#00FF00"))
+			Foreground(colorSuccess)
 )
 
-// Configuration holds the Harvest API credentials
+// Configuration holds the Harvest API credentials and user preferences
 type Configuration struct {
-	AccountID   string
-	AccessToken string
-	BaseURL     string
+	AccountID   string `json:"-"`
+	AccessToken string `json:"-"`
+	BaseURL     string `json:"base_url,omitempty"`
+
+	// API version path appended to the base URL, "v2" by default
+	APIVersion string `json:"api_version,omitempty"`
+
+	// Polling intervals in seconds, zero means the default
+	TickIntervalSeconds      int  `json:"tick_interval_seconds,omitempty"`
+	ReconcileIntervalSeconds int  `json:"reconcile_interval_seconds,omitempty"`
+	RefreshIntervalSeconds   int  `json:"refresh_interval_seconds,omitempty"`
+	HighPrecision            bool `json:"high_precision,omitempty"`
+
+	// Page size for paginated API calls, zero means the default
+	PerPage int `json:"per_page,omitempty"`
+
+	// HTTP connection pool tuning, zero means the default. Keeping idle
+	// connections open avoids a new TLS handshake for every page fetched.
+	MaxIdleConns           int  `json:"max_idle_conns,omitempty"`
+	MaxIdleConnsPerHost    int  `json:"max_idle_conns_per_host,omitempty"`
+	IdleConnTimeoutSeconds int  `json:"idle_conn_timeout_seconds,omitempty"`
+	KeepAliveSeconds       int  `json:"keep_alive_seconds,omitempty"`
+	DisableKeepAlives      bool `json:"disable_keep_alives,omitempty"`
+
+	// Hide the "Will track" summary shown before starting a timer
+	HidePreview bool `json:"hide_preview,omitempty"`
+
+	// Show the today's entries panel on startup
+	ShowToday bool `json:"show_today,omitempty"`
+
+	// Consecutive failed requests before pausing requests, and for how
+	// long (defaults 5 and 60)
+	BreakerThreshold       int `json:"breaker_threshold,omitempty"`
+	BreakerCooldownSeconds int `json:"breaker_cooldown_seconds,omitempty"`
+
+	// Turn off actions that delete or overwrite entries: deleting, bulk
+	// editing, discarding short timers and overwriting on import
+	SafeMode bool `json:"safe_mode,omitempty"`
+
+	// Show the month's billable amount in the dashboard, in the currency
+	// given as a symbol ("€") or code ("EUR")
+	ShowEarnings bool   `json:"show_earnings,omitempty"`
+	Currency     string `json:"currency,omitempty"`
+
+	// Extra feedback on timer start/stop: "inline" (default), "banner" or
+	// "flash". Reduce motion turns the flash into a plain banner.
+	Feedback     string `json:"feedback,omitempty"`
+	ReduceMotion bool   `json:"reduce_motion,omitempty"`
+
+	// Turn off the 1-9 shortcuts for picking list items
+	DisableQuickSelect bool `json:"disable_quick_select,omitempty"`
+
+	// List item layout, "comfortable" (two lines) or "compact" (one line)
+	ListDensity string `json:"list_density,omitempty"`
+
+	// Hours estimated per project ID, shown against the hours tracked.
+	// Projects with an hours budget in Harvest don't need one.
+	Estimates map[int]float64 `json:"estimates,omitempty"`
+
+	// Show API request counts against the rate limit
+	Diagnostics bool `json:"diagnostics,omitempty"`
+
+	// Timers stopped before running this many minutes offer to discard
+	// the entry instead of logging it. Zero logs everything.
+	MinimumMinutes int `json:"minimum_minutes,omitempty"`
+
+	// Confirm deleting summary entries "always" (default), "never", or only
+	// for entries "long"er than delete_confirm_hours
+	DeleteConfirm      string  `json:"delete_confirm,omitempty"`
+	DeleteConfirmHours float64 `json:"delete_confirm_hours,omitempty"`
+
+	// When starting a timer stops one that ran for less than this many
+	// seconds, delete the stopped entry instead of logging a fragment
+	SwitchGraceSeconds int `json:"switch_grace_seconds,omitempty"`
+
+	// Stop the running timer after this many minutes without keyboard or
+	// mouse input, optionally asking first. Off when zero.
+	IdleStopMinutes int  `json:"idle_stop_minutes,omitempty"`
+	ConfirmIdleStop bool `json:"confirm_idle_stop,omitempty"`
+
+	// Don't keep a copy of half-typed notes on disk
+	DisableDrafts bool `json:"disable_drafts,omitempty"`
+
+	// Matcher for the list filters, "ranked" (default) or "fuzzy"
+	ListFilter string `json:"list_filter,omitempty"`
+
+	// Ask before starting a timer outside working hours
+	ConfirmOffHours bool      `json:"confirm_off_hours,omitempty"`
+	WorkHours       WorkHours `json:"work_hours,omitzero"`
+
+	// First day of the week for weekly summaries and reports, e.g. "sun".
+	// Defaults to Monday.
+	WeekStart string `json:"week_start,omitempty"`
+
+	// IANA time zone used for dates and work hours instead of the system
+	// one, e.g. "Europe/Berlin"
+	Timezone string `json:"timezone,omitempty"`
+
+	// Harvest account subdomain, used to build web URLs
+	Subdomain string `json:"subdomain,omitempty"`
+
+	// Shortcuts to a project/task pair, used by the start command
+	Aliases map[string]Alias `json:"aliases,omitempty"`
+
+	// Key overrides by action name, replacing the default keys
+	Keys map[string][]string `json:"keys,omitempty"`
+
+	// Flag summary entries without notes, except for the listed task names
+	RequireNotes       bool     `json:"require_notes,omitempty"`
+	NotesOptionalTasks []string `json:"notes_optional_tasks,omitempty"`
+
+	// Nudge to start a timer when the terminal regains focus during work
+	// hours with none running, at most once per interval (30 by default)
+	FocusNudge        bool `json:"focus_nudge,omitempty"`
+	FocusNudgeMinutes int  `json:"focus_nudge_minutes,omitempty"`
+
+	// Put between existing notes and appended text, " — " by default
+	NoteSeparator string `json:"note_separator,omitempty"`
+
+	// Keys such as "f2" that start a timer for an alias in one keystroke
+	QuickActions map[string]QuickAction `json:"quick_actions,omitempty"`
+}
+
+// Alias maps a short name to a project and task
+type Alias struct {
+	ProjectID   int    `json:"project_id"`
+	TaskID      int    `json:"task_id"`
+	ProjectName string `json:"project_name,omitempty"`
+	TaskName    string `json:"task_name,omitempty"`
 }
 
 // HarvestClient handles API communication
 type HarvestClient struct {
 	config Configuration
 	client *resty.Client
+	stats  *requestStats
+
+	// When set, time entries and the running timer are only fetched for
+	// this user, since admin and manager tokens also see everyone else's
+	userID int
 }
 
 // Project represents a Harvest project
 type Project struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Client      string `json:"client"`
+	LastTracked string `json:"last_tracked"`
 }
 
 // Task represents a Harvest task
 type Task struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Billable    bool   `json:"billable"`
+	LastTracked string `json:"last_tracked"`
 }
 
 // Timer represents a running Harvest timer
 type Timer struct {
-	ID        int     `json:"id"`
-	Notes     string  `json:"notes"`
-	Hours     float64 `json:"hours"`
-	ProjectID int     `json:"project_id"`
-	TaskID    int     `json:"task_id"`
-	IsRunning bool    `json:"is_running"`
+	ID                int                `json:"id"`
+	Notes             string             `json:"notes"`
+	Hours             float64            `json:"hours"`
+	IsRunning         bool               `json:"is_running"`
+	Billable          bool               `json:"billable"`
+	SpentDate         string             `json:"spent_date"`
+	ExternalReference *ExternalReference `json:"external_reference"`
+
+	// Harvest nests these as project and task objects, see UnmarshalJSON.
+	// The names may also be resolved locally for a timer restored at startup.
+	ProjectID   int    `json:"-"`
+	TaskID      int    `json:"-"`
+	ProjectName string `json:"-"`
+	TaskName    string `json:"-"`
+}
+
+// Decode a time entry, taking the project and task from their nested
+// objects
+func (t *Timer) UnmarshalJSON(data []byte) error {
+	type plain Timer
+	var entry struct {
+		plain
+		Project *struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"project"`
+		Task *struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"task"`
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return err
+	}
+
+	*t = Timer(entry.plain)
+	if entry.Project != nil {
+		t.ProjectID, t.ProjectName = entry.Project.ID, entry.Project.Name
+	}
+	if entry.Task != nil {
+		t.TaskID, t.TaskName = entry.Task.ID, entry.Task.Name
+	}
+	return nil
+}
+
+// Keep what is known locally about a timer when a refetched copy of the
+// same timer lacks it
+func (t *Timer) carryDetails(previous *Timer) {
+	if t.ProjectID == 0 {
+		t.ProjectID = previous.ProjectID
+	}
+	if t.TaskID == 0 {
+		t.TaskID = previous.TaskID
+	}
+	if t.ProjectName == "" {
+		t.ProjectName = previous.ProjectName
+	}
+	if t.TaskName == "" {
+		t.TaskName = previous.TaskName
+	}
+}
+
+// ExternalReference links an entry to an item in another tool
+type ExternalReference struct {
+	Permalink string `json:"permalink"`
 }
 
 // ListItem for bubbles list
 type ListItem struct {
-	ID   int
-	Name string
+	ID     int
+	Name   string
+	Detail string
 }
 
 func (i ListItem) FilterValue() string { return i.Name }
 func (i ListItem) Title() string       { return i.Name }
-func (i ListItem) Description() string { return fmt.Sprintf("ID: %d", i.ID) }
+func (i ListItem) Description() string { return i.Detail }
 
 // Model represents the application state
 type Model struct {
@@ -88,25 +296,91 @@ type Model struct {
 	selectedProject Project
 	selectedTask    Task
 	ticketInput     textinput.Model
+	aliasInput      textinput.Model
 	projectList     list.Model
 	taskList        list.Model
+	searchList      list.Model
+	searchTasks     []searchTask
+	searchReturn    string
 	activeTimer     *Timer
+	timerStartedAt  time.Time
+	tickID          int
+	config          Configuration
+	keys            keyMap
+	showToday       bool
+	todayEntries    []TimeEntry
+	width           int
+	height          int
+	summaryDate     string
+	summaryReturn   string
+	summaryCursor   int
+	summaryProject  Project
+	summaryEntries  []TimeEntry
+	budgets         map[int]projectBudget
+	invoices        []Invoice
+	invoiceCursor   int
+	invoiceNextPage int
+	invoicesReturn  string
+	invoicesDenied  bool
+	summarySelected map[int]bool
+	summaryNavID    int
+	summaryLoading  bool
+	spinner         spinner.Model
+	bulkInput       textinput.Model
+	bulkQueue       []TimeEntry
+	bulkTotal       int
+	bulkLog         []string
+	bulkReturn      string
+	deleteEntry     TimeEntry
+	webBase         string
+	projectsSkipped int
+	deleteReturn    string
+	entryCache      map[string]*entryCache
+	projectHours    map[int]*projectHours
+	settingsDraft   Configuration
+	settingsReturn  string
+	settingsCursor  int
+	settingsInput   textinput.Model
+	skipOffHours    bool
+	startPending    bool
+	restoring       bool
+	draftID         int
+	history         notesHistory
+	recent          []recentUse
+	quickPreview    quickPreview
+	nudge           bool
+	nudgeID         int
+	lastNudge       time.Time
+	swapTask        Task
+	historyCursor   int
+	historyStash    string // notes typed before browsing the history
+	editedNotes     string // notes from the external editor, line breaks kept
+	editedShown     string // editedNotes as the single-line notes field shows them
+	windowID        int
+	tentative       bool
+	idleSince       time.Time
+	idleReturn      string
+	discardReturn   string
+	resumeReturn    string
+	banner          string
+	bannerID        int
+	notice          string
+	noticeID        int
+	flashing        bool
+	warning         string
 	error           string
 	success         string
 	quitting        bool
 	showHelp        bool
+	focusMode       bool
 }
 
 // Initialize the Harvest client
 func NewHarvestClient(config Configuration) *HarvestClient {
-	// Default to the API URL if none provided
-	if config.BaseURL == "" {
-		config.BaseURL = defaultBaseURL
-	}
-
 	// Create resty client with TLS configuration
 	client := resty.New()
-	client.SetBaseURL(config.BaseURL)
+	client.SetTransport(newTransport(config))
+	client.SetBaseURL(config.apiURL())
 	client.SetHeader("Harvest-Account-ID", config.AccountID)
 	client.SetHeader("Authorization", "Bearer "+config.AccessToken)
 	client.SetHeader("User-Agent", "Harvest-TUI/1.0 (your-email@example.com)")
@@ -116,9 +390,14 @@ func NewHarvestClient(config Configuration) *HarvestClient {
 	// Set TLS configuration for secure HTTPS connections
 	client.SetTLSClientConfig(nil) // Use default which validates certificates
 
+	newCircuitBreaker(config).attach(client)
+	stats := &requestStats{}
+	stats.attach(client)
+
 	return &HarvestClient{
 		config: config,
 		client: client,
+		stats:  stats,
 	}
 }
 
@@ -139,42 +418,64 @@ func (h *HarvestClient) TestConnection() error {
 	return nil
 }
 
-// Fetch user's recent/active projects through timesheet data
-func (h *HarvestClient) GetProjects() ([]Project, error) {
+// Fetch user's recent/active projects through timesheet data. Also returns
+// how many entries were skipped because their project no longer exists.
+func (h *HarvestClient) GetProjects() ([]Project, int, error) {
 	// Try the time entries endpoint to get recent projects
 	resp, err := h.client.R().
 		SetResult(struct {
 			TimeEntries []struct {
-				Project struct {
+				Project *struct {
 					ID   int    `json:"id"`
 					Name string `json:"name"`
 				} `json:"project"`
+				Client struct {
+					Name string `json:"name"`
+				} `json:"client"`
+				SpentDate string `json:"spent_date"`
 			} `json:"time_entries"`
 		}{}).
-		Get("/time_entries?per_page=100")
+		Get(fmt.Sprintf("/time_entries?per_page=%d", h.config.perPage()))
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	if resp.IsError() {
-		return nil, fmt.Errorf("Failed to fetch projects: %v", err)
+		return nil, 0, fmt.Errorf("Failed to fetch projects: %v", err)
 	}
 
 	result := resp.Result().(*struct {
 		TimeEntries []struct {
-			Project struct {
+			Project *struct {
 				ID   int    `json:"id"`
 				Name string `json:"name"`
 			} `json:"project"`
+			Client struct {
+				Name string `json:"name"`
+			} `json:"client"`
+			SpentDate string `json:"spent_date"`
 		} `json:"time_entries"`
 	})
 
-	// Extract unique projects from time entries
+	// Extract unique projects from time entries, skipping entries whose
+	// project was deleted (null in the response)
 	projectMap := make(map[int]Project)
+	skipped := 0
 	for _, entry := range result.TimeEntries {
+		if entry.Project == nil || entry.Project.ID == 0 {
+			skipped++
+			continue
+		}
+
+		lastTracked := entry.SpentDate
+		if existing, ok := projectMap[entry.Project.ID]; ok && existing.LastTracked > lastTracked {
+			lastTracked = existing.LastTracked
+		}
 		projectMap[entry.Project.ID] = Project{
-			ID:   entry.Project.ID,
-			Name: entry.Project.Name,
+			ID:          entry.Project.ID,
+			Name:        entry.Project.Name,
+			Client:      entry.Client.Name,
+			LastTracked: lastTracked,
 		}
 	}
 
@@ -183,7 +484,7 @@ func (h *HarvestClient) GetProjects() ([]Project, error) {
 		projects = append(projects, project)
 	}
 
-	return projects, nil
+	return projects, skipped, nil
 }
 
 // Fetch tasks for a specific project
@@ -192,13 +493,15 @@ func (h *HarvestClient) GetTasks(projectID int) ([]Task, error) {
 	resp, err := h.client.R().
 		SetResult(struct {
 			TimeEntries []struct {
-				Task struct {
+				Task *struct {
 					ID   int    `json:"id"`
 					Name string `json:"name"`
 				} `json:"task"`
+				Billable  bool   `json:"billable"`
+				SpentDate string `json:"spent_date"`
 			} `json:"time_entries"`
 		}{}).
-		Get(fmt.Sprintf("/time_entries?project_id=%d&per_page=100", projectID))
+		Get(fmt.Sprintf("/time_entries?project_id=%d&per_page=%d", projectID, h.config.perPage()))
 	if err != nil {
 		return nil, err
 	}
@@ -209,19 +512,32 @@ func (h *HarvestClient) GetTasks(projectID int) ([]Task, error) {
 
 	result := resp.Result().(*struct {
 		TimeEntries []struct {
-			Task struct {
+			Task *struct {
 				ID   int    `json:"id"`
 				Name string `json:"name"`
 			} `json:"task"`
+			Billable  bool   `json:"billable"`
+			SpentDate string `json:"spent_date"`
 		} `json:"time_entries"`
 	})
 
-	// Extract unique tasks from time entries
+	// Extract unique tasks from time entries, skipping entries whose task
+	// was deleted (null in the response)
 	taskMap := make(map[int]Task)
 	for _, entry := range result.TimeEntries {
+		if entry.Task == nil || entry.Task.ID == 0 {
+			continue
+		}
+
+		lastTracked := entry.SpentDate
+		if existing, ok := taskMap[entry.Task.ID]; ok && existing.LastTracked > lastTracked {
+			lastTracked = existing.LastTracked
+		}
 		taskMap[entry.Task.ID] = Task{
-			ID:   entry.Task.ID,
-			Name: entry.Task.Name,
+			ID:          entry.Task.ID,
+			Name:        entry.Task.Name,
+			Billable:    entry.Billable,
+			LastTracked: lastTracked,
 		}
 	}
 
@@ -241,18 +557,10 @@ func (h *HarvestClient) StartTimer(projectID, taskID int, notes string) (*Timer,
 		"notes":      notes,
 	}
 
-	var timerResp struct {
-		ID        int     `json:"id"`
-		Notes     string  `json:"notes"`
-		Hours     float64 `json:"hours"`
-		ProjectID int     `json:"project_id"`
-		TaskID    int     `json:"task_id"`
-		IsRunning bool    `json:"is_running"`
-	}
-
+	var timer Timer
 	resp, err := h.client.R().
 		SetBody(payload).
-		SetResult(&timerResp).
+		SetResult(&timer).
 		Post("/time_entries")
 	if err != nil {
 		return nil, err
@@ -262,16 +570,33 @@ func (h *HarvestClient) StartTimer(projectID, taskID int, notes string) (*Timer,
 		return nil, fmt.Errorf("API error: Status=%d, Body=%s", resp.StatusCode(), resp.String())
 	}
 
-	timer := &Timer{
-		ID:        timerResp.ID,
-		Notes:     timerResp.Notes,
-		Hours:     timerResp.Hours,
-		ProjectID: timerResp.ProjectID,
-		TaskID:    timerResp.TaskID,
-		IsRunning: timerResp.IsRunning,
+	return &timer, nil
+}
+
+// Create a completed (non-running) time entry for a given day
+func (h *HarvestClient) CreateTimeEntry(projectID, taskID int, spentDate string, hours float64, notes string) (*Timer, error) {
+	payload := map[string]interface{}{
+		"project_id": projectID,
+		"task_id":    taskID,
+		"spent_date": spentDate,
+		"hours":      hours,
+		"notes":      notes,
+	}
+
+	var entry Timer
+	resp, err := h.client.R().
+		SetBody(payload).
+		SetResult(&entry).
+		Post("/time_entries")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("API error: Status=%d, Body=%s", resp.StatusCode(), resp.String())
 	}
 
-	return timer, nil
+	return &entry, nil
 }
 
 // Stop a running timer
@@ -289,101 +614,445 @@ func (h *HarvestClient) StopTimer(timerID int) error {
 	return nil
 }
 
+// Fetch the currently running timer, if any, the client's user's when it
+// has one
+func (h *HarvestClient) GetRunningTimer() (*Timer, error) {
+	query := "is_running=true"
+	if h.userID != 0 {
+		query += fmt.Sprintf("&user_id=%d", h.userID)
+	}
+
+	resp, err := h.client.R().
+		SetResult(struct {
+			TimeEntries []Timer `json:"time_entries"`
+		}{}).
+		Get("/time_entries?" + query)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("API error: Status=%d, Body=%s", resp.StatusCode(), resp.String())
+	}
+
+	result := resp.Result().(*struct {
+		TimeEntries []Timer `json:"time_entries"`
+	})
+
+	if len(result.TimeEntries) == 0 {
+		return nil, nil
+	}
+
+	return &result.TimeEntries[0], nil
+}
+
 // Initialize the application model
 func initialModel(config Configuration) Model {
 	harvestClient := NewHarvestClient(config)
 
+	// The config file was validated on load, so the key map builds
+	keys, _ := newKeyMap(config.Keys)
+
 	// Initialize text input for ticket/title
 	ticketInput := textinput.New()
 	ticketInput.Placeholder = "Ticket-123 - Description of work"
 	ticketInput.Focus()
 	ticketInput.Width = 50
+	ticketInput.CharLimit = maxNotesLength
+
+	// Initialize text input for alias names
+	aliasInput := textinput.New()
+	aliasInput.Placeholder = "alias"
+	aliasInput.Width = 30
+
+	// Initialize text input for editing settings
+	settingsInput := textinput.New()
+	settingsInput.Width = 30
+
+	// Initialize text input for bulk editing notes
+	bulkInput := textinput.New()
+	bulkInput.Placeholder = "New notes, +text to append, or find => replace"
+	bulkInput.Width = 50
+	bulkInput.CharLimit = maxNotesLength
 
 	// Initialize list models
-	projectList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	delegate := newItemDelegate(config.ListDensity, !config.DisableQuickSelect)
+	projectList := list.New([]list.Item{}, delegate, 0, 0)
 	projectList.Title = "Select Project"
 	projectList.SetShowStatusBar(false)
 	projectList.SetFilteringEnabled(true)
+	projectList.Filter = listFilter(config.ListFilter)
 	projectList.Styles.Title = lipgloss.NewStyle().Bold(true)
 
-	taskList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	taskList := list.New([]list.Item{}, delegate, 0, 0)
 	taskList.Title = "Select Task"
 	taskList.SetShowStatusBar(false)
 	taskList.SetFilteringEnabled(true)
+	taskList.Filter = listFilter(config.ListFilter)
 	taskList.Styles.Title = lipgloss.NewStyle().Bold(true)
 
-	return Model{
+	searchList := list.New([]list.Item{}, delegate, 0, 0)
+	searchList.Title = "Search Tasks"
+	searchList.SetShowStatusBar(false)
+	searchList.SetFilteringEnabled(true)
+	searchList.Filter = listFilter(config.ListFilter)
+	searchList.Styles.Title = lipgloss.NewStyle().Bold(true)
+
+	m := Model{
 		harvestClient: harvestClient,
+		config:        config,
+		keys:          keys,
+		showToday:     config.ShowToday,
+		entryCache:    make(map[string]*entryCache),
+		history:       loadHistory(),
+		recent:        loadRecent(),
 		state:         "loading_projects",
+		restoring:     true,
 		ticketInput:   ticketInput,
+		aliasInput:    aliasInput,
+		settingsInput: settingsInput,
+		bulkInput:     bulkInput,
 		projectList:   projectList,
 		taskList:      taskList,
+		searchList:    searchList,
+		spinner:       spinner.New(spinner.WithSpinner(spinner.Dot)),
+		webBase:       accountWebBase(config),
 	}
+
+	// Lay out the first frame for the last known size until the terminal
+	// reports its actual size
+	if size, ok := loadWindowSize(); ok {
+		m.width, m.height = size.Width, size.Height
+		m.resizeLists()
+	}
+
+	return m
 }
 
 // Define TUI messages
 type (
-	fetchProjectsMsg struct{ projects []Project }
-	fetchTasksMsg    struct{ tasks []Task }
-	startTimerMsg    struct{ timer *Timer }
-	stopTimerMsg     struct{ success bool }
-	errorMsg         struct{ error string }
+	fetchProjectsMsg struct {
+		projects []Project
+		skipped  int // entries whose project was deleted
+	}
+	fetchTasksMsg struct {
+		projectID int
+		tasks     []Task
+	}
+	startTimerMsg struct{ timer *Timer }
+	stopTimerMsg  struct {
+		success bool
+		tracked time.Duration // set when trimmed, otherwise the local elapsed time
+	}
+	runningTimerMsg struct{ timer *Timer }
+	startErrorMsg   struct{ error string }
+	configSavedMsg  struct{ message string }
+	todayEntriesMsg struct{ entries []TimeEntry }
+	tickMsg         struct{ id int }
+	reconcileMsg    struct{ id int }
+	errorMsg        struct{ error string }
+	loggedEntryMsg  struct {
+		entry *TimeEntry
+		local time.Duration
+	}
 )
 
 // Init initializes the model with the first command
 func (m Model) Init() tea.Cmd {
-	return fetchProjects(m.harvestClient)
+	return tea.Batch(
+		fetchProjects(m.harvestClient),
+		fetchRunningTimer(m.harvestClient),
+		m.refreshToday(),
+		scheduleRefresh(m.config.refreshInterval()),
+		checkTimezone(m.harvestClient),
+		m.lookupWebBase(),
+	)
+}
+
+// Track a running timer locally and restart the tick loops for it
+func (m *Model) trackTimer(timer *Timer) tea.Cmd {
+	m.activeTimer = timer
+	m.timerStartedAt = time.Now().Add(-time.Duration(timer.Hours * float64(time.Hour)))
+	m.tickID++
+
+	cmds := []tea.Cmd{
+		tickElapsed(m.tickID, nextTickDelay(m.elapsed(), m.config)),
+		reconcileTimer(m.tickID, m.config.reconcileInterval()),
+	}
+	if m.config.idleStopAfter() > 0 {
+		cmds = append(cmds, checkIdle(m.tickID))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Forget the active timer once it's stopped, discarded or deleted, along
+// with the state that only applies while one runs
+func (m *Model) clearTimer() {
+	m.activeTimer = nil
+	m.tentative = false
+	m.focusMode = false
+}
+
+// Elapsed time of the active timer, interpolated from its local start time
+func (m Model) elapsed() time.Duration {
+	if m.activeTimer == nil {
+		return 0
+	}
+	return time.Since(m.timerStartedAt)
 }
 
 // Update function for the Bubble Tea framework
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
-			m.quitting = true
-			return m, tea.Quit
-		case "?":
-			m.showHelp = !m.showHelp
-			return m, nil
-		case "esc":
-			if m.showHelp {
+		// Any key dismisses the nudge and still does its usual thing
+		if m.nudge {
+			m.nudge = false
+			m.resizeLists()
+		}
+
+		// The alias prompt owns the keyboard while it is open
+		if m.state == "assign_alias" {
+			return m.updateAliasInput(msg)
+		}
+
+		if m.state == "confirm_off_hours" && msg.String() != "ctrl+c" {
+			return m.updateOffHoursPrompt(msg)
+		}
+
+		if m.focusMode && m.activeTimer != nil && msg.String() != "ctrl+c" {
+			return m.updateFocusOverlay(msg)
+		}
+
+		// Help covers the whole screen, so keys must not reach the list,
+		// filter or input underneath. Leaving the screen untouched brings
+		// back the same filter, selection and scroll position on close.
+		if m.showHelp {
+			switch m.keys.action(msg.String()) {
+			case "quit":
+				m.quitting = true
+				return m, tea.Quit
+			case "help", "back":
 				m.showHelp = false
-				return m, nil
 			}
+			return m, nil
+		}
+
+		if m.state == "resume_timer" && msg.String() != "ctrl+c" {
+			return m.updateResumePrompt(msg)
+		}
 
+		if m.state == "confirm_tentative" && msg.String() != "ctrl+c" {
+			return m.updateTentativePrompt(msg)
+		}
+
+		if m.state == "confirm_quick_action" && msg.String() != "ctrl+c" {
+			return m.updateQuickActionPrompt(msg)
+		}
+
+		if m.state == "confirm_delete" && msg.String() != "ctrl+c" {
+			return m.updateDeletePrompt(msg)
+		}
+
+		if m.state == "confirm_discard" && msg.String() != "ctrl+c" {
+			return m.updateDiscardPrompt(msg)
+		}
+
+		if m.state == "confirm_idle_stop" && msg.String() != "ctrl+c" {
+			return m.updateIdlePrompt(msg)
+		}
+
+		if m.state == "bulk_edit" && msg.String() != "ctrl+c" {
+			return m.updateBulkEdit(msg)
+		}
+
+		// The settings screen handles its own keys, including Esc
+		if m.state == "settings" && msg.String() != "ctrl+c" {
+			return m.updateSettings(msg)
+		}
+
+		if m.state == "daily_summary" || m.state == "weekly_summary" {
+			switch m.keys.action(msg.String()) {
+			case "quit", "help":
+			default:
+				return m.updateSummary(msg)
+			}
+		}
+
+		if m.state == "invoices" {
+			switch m.keys.action(msg.String()) {
+			case "quit", "help":
+			default:
+				return m.updateInvoices(msg)
+			}
+		}
+
+		// ↑/↓ pick past notes for the task on the details screen
+		if m.state == "enter_details" && m.browseHistory(msg.String()) {
+			return m, nil
+		}
+
+		// While typing, keys go to the focused input or filter instead of
+		// triggering global shortcuts
+		if m.typing() && !m.globalWhileTyping(msg.String()) {
+			break
+		}
+
+		// Function keys (or other configured keys) start a preset timer
+		if model, cmd, ok := m.runQuickAction(msg.String()); ok {
+			return model, cmd
+		}
+
+		// Digits pick one of the first nine list items directly
+		if n, ok := quickSelectIndex(msg.String()); ok && !m.config.DisableQuickSelect {
 			switch m.state {
+			case "select_project":
+				if n < len(m.projectList.VisibleItems()) {
+					m.projectList.Select(n)
+					return m.selectHighlighted()
+				}
 			case "select_task":
+				if n < len(m.taskList.VisibleItems()) {
+					m.taskList.Select(n)
+					return m.selectHighlighted()
+				}
+			case "search_tasks":
+				if n < len(m.searchList.VisibleItems()) {
+					m.searchList.Select(n)
+					return m.selectSearchResult()
+				}
+			}
+		}
+
+		switch m.keys.action(msg.String()) {
+		case "quit":
+			m.quitting = true
+			return m, tea.Quit
+		case "help":
+			m.showHelp = !m.showHelp
+			return m, nil
+		case "back":
+			switch m.state {
+			case "select_task", "loading_tasks":
 				m.state = "select_project"
 				return m, nil
 			case "enter_details":
 				m.state = "select_task"
 				return m, nil
+			case "search_tasks", "loading_search":
+				m.state = m.searchReturn
+				return m, nil
 			}
-		case "enter":
-			m.error = ""
-			m.success = ""
-
+		case "external_editor":
+			if m.state == "enter_details" {
+				return m.openEditor()
+			}
+		case "toggle_focus":
+			if m.state == "enter_details" {
+				if m.ticketInput.Focused() {
+					m.ticketInput.Blur()
+					return m, nil
+				}
+				m.ticketInput.Focus()
+				return m, textinput.Blink
+			}
+		case "daily_summary":
+			switch m.state {
+			case "select_project", "select_task", "enter_details":
+				return m.openSummary("daily_summary")
+			}
+		case "settings":
+			switch m.state {
+			case "select_project", "select_task", "enter_details":
+				return m.openSettings()
+			}
+		case "weekly_summary":
 			switch m.state {
+			case "select_project", "select_task", "enter_details":
+				return m.openSummary("weekly_summary")
+			}
+		case "invoices":
+			switch m.state {
+			case "select_project", "select_task", "enter_details":
+				return m.openInvoices()
+			}
+		case "search_tasks":
+			switch m.state {
+			case "select_project", "select_task", "enter_details":
+				return m.openSearch()
+			}
+		case "swap_project":
+			switch m.state {
+			case "select_project", "select_task", "enter_details":
+				return m.swapProject()
+			}
+		case "refresh":
+			switch m.state {
+			case "search_tasks":
+				// Reload the task index, e.g. after being added to a project
+				return m, fetchSearchIndex(m.harvestClient)
 			case "select_project":
-				if len(m.projects) > 0 {
-					idx := m.projectList.Index()
-					if idx >= 0 && idx < len(m.projects) {
-						m.selectedProject = m.projects[idx]
-						m.state = "loading_tasks"
-						return m, fetchTasks(m.harvestClient, m.selectedProject.ID)
-					}
+				return m, fetchProjects(m.harvestClient)
+			}
+		case "copy_url":
+			// Copy the web URL of the running timer
+			if m.state == "enter_details" && m.activeTimer != nil {
+				return m.copyEntryURL(m.activeTimer.SpentDate, m.activeTimer.ExternalReference)
+			}
+		case "focus_mode":
+			switch m.state {
+			case "select_project", "select_task", "enter_details":
+				if m.activeTimer != nil {
+					m.focusMode = true
+					return m, nil
 				}
-			case "select_task":
-				if len(m.tasks) > 0 {
-					idx := m.taskList.Index()
-					if idx >= 0 && idx < len(m.tasks) {
-						m.selectedTask = m.tasks[idx]
-						m.state = "enter_details"
-						m.ticketInput.Focus()
-						return m, nil
-					}
+			}
+		case "toggle_billable":
+			if m.state == "enter_details" && m.activeTimer != nil {
+				return m.toggleBillable()
+			}
+		case "tentative":
+			// Mark the running timer as needing its notes finalized
+			if m.state == "enter_details" && m.activeTimer != nil {
+				m.tentative = !m.tentative
+				return m, nil
+			}
+		case "toggle_today":
+			// Toggle the today's entries panel, fetching it when shown
+			m.showToday = !m.showToday
+			m.resizeLists()
+			if !m.showToday {
+				return m, nil
+			}
+			return m, m.refreshToday()
+		case "notes":
+			// Jump straight to the notes field of the current project/task
+			switch m.state {
+			case "select_project", "select_task", "enter_details":
+				return m.focusNotes()
+			}
+		case "alias":
+			// Assign an alias to the highlighted project/task
+			if m.state == "select_task" {
+				if task, ok := m.highlightedTask(); ok {
+					m.selectedTask = task
+					m.error = ""
+					m.success = ""
+					m.state = "assign_alias"
+					m.aliasInput.SetValue("")
+					m.aliasInput.Focus()
+					return m, textinput.Blink
 				}
+			}
+		case "select":
+			m.error = ""
+			m.success = ""
+
+			switch m.state {
+			case "select_project", "select_task":
+				return m.selectHighlighted()
+			case "search_tasks":
+				return m.selectSearchResult()
 			case "enter_details":
 				if m.ticketInput.Value() == "" {
 					m.error = "Please enter ticket number and description"
@@ -392,71 +1061,335 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				// If we have an active timer, stop it
 				if m.activeTimer != nil {
-					return m, stopTimer(m.harvestClient, m.activeTimer.ID)
+					return m.stopOrFinalize()
 				}
 
 				// Otherwise start a new timer
-				return m, startTimer(
-					m.harvestClient,
-					m.selectedProject.ID,
-					m.selectedTask.ID,
-					m.ticketInput.Value(),
-				)
+				return m.startWithinHours()
 			}
 		}
 
 	case fetchProjectsMsg:
 		m.projects = msg.projects
-		m.state = "select_project"
+		m.projectsSkipped = msg.skipped
+		switch m.state {
+		case "loading_projects":
+			m.state = "select_project"
+		case "resume_timer":
+			m.resumeReturn = "select_project"
+		}
+
+		m.setProjectItems()
+		return m, m.fetchBudgets()
+
+	case refreshMsg:
+		return m, tea.Batch(m.backgroundRefresh(), scheduleRefresh(m.config.refreshInterval()))
 
-		// Convert projects to list items
-		items := make([]list.Item, len(m.projects))
-		for i, project := range m.projects {
-			items[i] = ListItem{ID: project.ID, Name: project.Name}
+	case refreshedMsg:
+		cmd := m.applyRefresh(msg)
+		return m, cmd
+
+	case timezoneMsg:
+		// Keep an existing warning, such as another running instance
+		if m.warning == "" {
+			m.warning = msg.warning
+		} else {
+			m.warning += "\n⚠ " + msg.warning
+		}
+		m.resizeLists()
+		return m, nil
+
+	case clearNoticeMsg:
+		if msg.id == m.noticeID {
+			m.notice = ""
+		}
+		return m, nil
+
+	case tea.FocusMsg:
+		cmd := m.nudgeOnFocus(time.Now())
+		return m, cmd
+
+	case editorDoneMsg:
+		return m.handleEditorDone(msg)
+
+	case clearNudgeMsg:
+		if msg.id == m.nudgeID {
+			m.nudge = false
+			m.resizeLists()
 		}
-		m.projectList.SetItems(items)
+		return m, nil
+
+	case searchIndexMsg:
+		m.setSearchItems(msg.tasks)
+		if m.state == "confirm_quick_action" && m.quickPreview.checking {
+			m.checkQuickAction(msg.tasks)
+		}
+		return m, nil
+
+	case budgetsMsg:
+		m.applyBudgets(msg)
+		m.setProjectItems()
+		m.resizeLists()
+		return m, nil
 
 	case fetchTasksMsg:
+		// Discard responses for a project that is no longer selected,
+		// which can arrive out of order when switching projects quickly
+		if msg.projectID != m.selectedProject.ID || m.state != "loading_tasks" {
+			return m, nil
+		}
+
 		m.tasks = msg.tasks
 		m.state = "select_task"
-
-		// Convert tasks to list items
-		items := make([]list.Item, len(m.tasks))
-		for i, task := range m.tasks {
-			items[i] = ListItem{ID: task.ID, Name: task.Name}
-		}
-		m.taskList.SetItems(items)
+		m.setTaskItems()
+		m.selectSwapTask()
 
 	case startTimerMsg:
-		m.activeTimer = msg.timer
+		m.startPending = false
+		m.tentative = false
 		m.success = fmt.Sprintf("Timer started for: %s", m.ticketInput.Value())
+		msg.timer.ProjectName, msg.timer.TaskName = m.selectedProject.Name, m.selectedTask.Name
+
+		// Harvest stopped the previous timer; drop it if it was a fragment
+		discardCmd := m.discardSwitched(msg.timer)
+
+		// The notes are on the server now
+		m.draftID++
+		draftCmd := saveDraft(draft{})
+		m.history.add(m.selectedProject.ID, m.selectedTask.ID, sanitizeNotes(m.notesText()))
+		m.historyCursor = 0
+		m.recent = addRecent(m.recent, m.selectedProject, m.selectedTask)
+
+		// Confirm against the server which timer is actually running
+		cmd := tea.Batch(
+			m.trackTimer(msg.timer),
+			fetchRunningTimer(m.harvestClient),
+			m.refreshToday(),
+			m.celebrate("Timer started"),
+			draftCmd,
+			historyFile.save(m.history),
+			recentFile.save(m.recent),
+			discardCmd,
+		)
+		return m, cmd
+
+	case startErrorMsg:
+		m.startPending = false
+		m.error = msg.error
+
+	case idleMsg:
+		return m.handleIdle(msg)
+
+	case invoicesMsg:
+		return m.handleInvoices(msg)
+
+	case billableMsg:
+		m.applyBillable(msg)
+		return m, nil
+
+	case bulkEditMsg:
+		return m.handleBulkEdit(msg)
+
+	case notesUpdatedMsg:
+		if m.activeTimer != nil && m.activeTimer.ID == msg.timerID {
+			m.activeTimer.Notes = msg.notes
+			m.tentative = false
+			m.ticketInput.Blur()
+			m.success = "Notes finalized, press Enter again to stop the timer"
+		}
+		return m, nil
 
 	case stopTimerMsg:
 		if msg.success {
 			m.success = "Timer stopped"
-			m.activeTimer = nil
-		} else {
-			m.error = "Failed to stop timer"
+			cmds := []tea.Cmd{m.refreshToday(), m.celebrate("Timer stopped")}
+
+			// Show the hours Harvest actually logged, which may be rounded
+			if m.activeTimer != nil {
+				tracked := msg.tracked
+				if tracked == 0 {
+					tracked = m.elapsed()
+				}
+				cmds = append(cmds, fetchLoggedEntry(m.harvestClient, m.activeTimer.ID, tracked))
+			}
+
+			m.clearTimer()
+			return m, tea.Batch(cmds...)
+		}
+		m.error = "Failed to stop timer"
+
+	case discardedMsg:
+		if m.activeTimer != nil && m.activeTimer.ID == msg.timerID {
+			m.clearTimer()
+		}
+		// A discarded switch fragment is already mentioned in the start message
+		if !msg.switched {
+			m.success = "Timer discarded, nothing was logged"
+		}
+		return m, m.refreshToday()
+
+	case loggedEntryMsg:
+		m.success = loggedMessage(msg.entry, msg.local)
+		return m, nil
+
+	case clearBannerMsg:
+		if msg.id == m.bannerID {
+			m.banner = ""
+			m.flashing = false
+		}
+		return m, nil
+
+	case endFlashMsg:
+		if msg.id == m.bannerID {
+			m.flashing = false
+		}
+		return m, nil
+
+	case copiedMsg:
+		m.success = fmt.Sprintf("Copied %s", msg.text)
+		return m, nil
+
+	case entriesMsg:
+		m.mergeEntries(msg)
+		if key, _, _ := m.summaryPeriod(); key == msg.key {
+			m.summaryEntries = m.summaryEntriesFor(key)
+			m.summaryCursor = min(m.summaryCursor, max(len(m.summaryEntries)-1, 0))
+			m.summaryLoading = false
+		}
+		return m, nil
+
+	case companyMsg:
+		m.webBase = msg.baseURI
+		if msg.baseURI == "" {
+			return m, nil
+		}
+		return m, cacheWebBase(m.config.AccountID, msg.baseURI)
+
+	case cacheErrorMsg:
+		m.error = msg.err.Error()
+		return m, nil
+
+	case entryDeletedMsg:
+		return m.handleEntryDeleted(msg)
+
+	case summaryNavMsg:
+		return m.handleSummaryNav(msg)
+
+	case spinner.TickMsg:
+		return m.handleSpinner(msg)
+
+	case todayEntriesMsg:
+		m.todayEntries = msg.entries
+		m.resizeLists()
+		return m, nil
+
+	case configSavedMsg:
+		m.success = msg.message
+
+	case runningTimerMsg:
+		// Only the check made at startup offers to resume the timer
+		restoring := m.restoring
+		m.restoring = false
+
+		switch {
+		case msg.timer == nil:
+			if m.activeTimer != nil {
+				m.clearTimer()
+				m.success = "Timer was stopped outside the TUI"
+			}
+		case m.activeTimer != nil && m.activeTimer.ID == msg.timer.ID:
+			// Same timer, just correct any local drift and pick up notes
+			// edited elsewhere (e.g. in the web UI)
+			if msg.timer.Notes != m.activeTimer.Notes {
+				if !m.ticketInput.Focused() && m.ticketInput.Value() == m.activeTimer.Notes {
+					m.ticketInput.SetValue(msg.timer.Notes)
+				}
+				m.success = fmt.Sprintf("Notes updated externally: %s", msg.timer.Notes)
+			}
+			msg.timer.carryDetails(m.activeTimer)
+			m.activeTimer = msg.timer
+			m.timerStartedAt = time.Now().Add(-time.Duration(msg.timer.Hours * float64(time.Hour)))
+		default:
+			cmd := m.trackTimer(msg.timer)
+			if !m.resolveTimerNames(m.activeTimer) {
+				cmd = tea.Batch(cmd, fetchTimerEntry(m.harvestClient, msg.timer.ID))
+			}
+			if restoring {
+				m.offerResume()
+			}
+			return m, cmd
+		}
+		return m, nil
+
+	case draftSaveMsg:
+		// Superseded by later typing or a started timer
+		if msg.id != m.draftID {
+			return m, nil
 		}
+		return m, saveDraft(draft{
+			ProjectID: m.selectedProject.ID,
+			TaskID:    m.selectedTask.ID,
+			Notes:     strings.TrimSpace(m.notesText()),
+		})
+
+	case timerEntryMsg:
+		m.applyTimerEntry(msg.entry)
+		return m, nil
+
+	case tickMsg:
+		// Drop ticks from a previous timer
+		if msg.id != m.tickID || m.activeTimer == nil {
+			return m, nil
+		}
+		return m, tickElapsed(m.tickID, nextTickDelay(m.elapsed(), m.config))
+
+	case reconcileMsg:
+		if msg.id != m.tickID || m.activeTimer == nil {
+			return m, nil
+		}
+		return m, tea.Batch(
+			fetchRunningTimer(m.harvestClient),
+			reconcileTimer(m.tickID, m.config.reconcileInterval()),
+		)
 
 	case errorMsg:
 		m.error = msg.error
+		m.summaryLoading = false
 		if m.state == "loading_projects" || m.state == "loading_tasks" {
 			m.state = "error"
 		}
+		if m.state == "loading_search" {
+			m.state = m.searchReturn
+		}
+		if m.state == "confirm_quick_action" && m.quickPreview.checking {
+			m.quickPreview.checking = false
+			m.quickPreview.unverified = msg.error
+			m.error = ""
+		}
 
 	case tea.WindowSizeMsg:
 		// Handle window size changes
-		h, v := docStyle.GetFrameSize()
-		m.projectList.SetSize(msg.Width-h, msg.Height-v)
-		m.taskList.SetSize(msg.Width-h, msg.Height-v)
+		m.width, m.height = msg.Width, msg.Height
+		m.resizeLists()
+		cmd := m.scheduleWindowSave()
+		return m, cmd
+
+	case windowSaveMsg:
+		if msg.id == m.windowID {
+			return m, windowFile.save(windowSize{Width: m.width, Height: m.height})
+		}
+		return m, nil
 	}
 
 	// Handle input updates
 	var cmd tea.Cmd
 	if m.state == "enter_details" {
 		if m.ticketInput.Focused() {
+			notes := m.ticketInput.Value()
 			m.ticketInput, cmd = m.ticketInput.Update(msg)
+			if m.activeTimer == nil && m.ticketInput.Value() != notes {
+				cmd = tea.Batch(cmd, m.scheduleDraftSave())
+			}
 			return m, cmd
 		}
 	}
@@ -470,11 +1403,198 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var cmd tea.Cmd
 		m.taskList, cmd = m.taskList.Update(msg)
 		return m, cmd
+	} else if m.state == "search_tasks" {
+		var cmd tea.Cmd
+		m.searchList, cmd = m.searchList.Update(msg)
+		return m, cmd
 	}
 
 	return m, nil
 }
 
+// Size the lists to the window, leaving room for the header, footer and panels
+func (m *Model) resizeLists() {
+	// Title and footer take four lines, the dashboard two more
+	h, v := docStyle.GetFrameSize()
+	height := m.height - v - 4 - 2 - m.todayPanelHeight() - m.diagnosticsHeight() - m.nudgeHeight()
+	if m.warning != "" {
+		height -= strings.Count(m.warning, "\n") + 2
+	}
+	m.projectList.SetSize(m.width-h, height)
+	m.searchList.SetSize(m.width-h, height)
+
+	// The task list sits below the project name and its estimate
+	taskHeight := height - 2
+	if _, ok := m.budgets[m.selectedProject.ID]; ok {
+		taskHeight--
+	}
+	m.taskList.SetSize(m.width-h, taskHeight)
+}
+
+// Command to start a timer for the selected project/task with the entered
+// notes. Marks the start as pending so repeated presses don't create a
+// second timer before the first one is confirmed.
+func (m *Model) startSelectedTimer() tea.Cmd {
+	m.startPending = true
+	return startTimer(
+		m.harvestClient,
+		m.selectedProject.ID,
+		m.selectedTask.ID,
+		sanitizeNotes(m.notesText()),
+	)
+}
+
+// Whether keystrokes currently belong to a text input or list filter
+func (m Model) typing() bool {
+	switch m.state {
+	case "enter_details":
+		return m.ticketInput.Focused()
+	case "select_project":
+		return m.projectList.FilterState() == list.Filtering
+	case "select_task":
+		return m.taskList.FilterState() == list.Filtering
+	case "search_tasks":
+		return m.searchList.FilterState() == list.Filtering
+	}
+	return false
+}
+
+// Keys that keep their global meaning while typing
+func (m Model) globalWhileTyping(key string) bool {
+	if key == "ctrl+c" {
+		return true
+	}
+
+	// Quick actions work from the notes field too, except on keys that
+	// type a character
+	if _, ok := m.config.QuickActions[key]; ok {
+		return m.state == "enter_details" && len([]rune(key)) > 1
+	}
+
+	switch m.keys.action(key) {
+	case "back", "select", "toggle_focus", "external_editor":
+		// List filters handle these themselves
+		return m.state == "enter_details"
+	}
+	return false
+}
+
+// Choose the highlighted project or task and move on to the next step
+func (m Model) selectHighlighted() (tea.Model, tea.Cmd) {
+	m.error = ""
+	m.success = ""
+
+	switch m.state {
+	case "select_project":
+		if project, ok := m.highlightedProject(); ok {
+			m.selectedProject = project
+			m.swapTask = Task{}
+			m.resizeLists()
+			m.state = "loading_tasks"
+			return m, fetchTasks(m.harvestClient, m.selectedProject.ID)
+		}
+	case "select_task":
+		if task, ok := m.highlightedTask(); ok {
+			m.selectedTask = task
+			m.state = "enter_details"
+			m.ticketInput.Focus()
+			m.historyCursor = 0
+			m.restoreDraft()
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// Zero-based list index for the quick-select keys 1-9
+func quickSelectIndex(key string) (int, bool) {
+	if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+		return int(key[0] - '1'), true
+	}
+	return 0, false
+}
+
+// Project highlighted in the project list, honoring any applied filter
+func (m Model) highlightedProject() (Project, bool) {
+	if item, ok := m.projectList.SelectedItem().(ListItem); ok {
+		for _, project := range m.projects {
+			if project.ID == item.ID {
+				return project, true
+			}
+		}
+	}
+	return Project{}, false
+}
+
+// Task highlighted in the task list, honoring any applied filter
+func (m Model) highlightedTask() (Task, bool) {
+	if item, ok := m.taskList.SelectedItem().(ListItem); ok {
+		for _, task := range m.tasks {
+			if task.ID == item.ID {
+				return task, true
+			}
+		}
+	}
+	return Task{}, false
+}
+
+// Focus the notes input, skipping project/task selection when both are known
+func (m Model) focusNotes() (tea.Model, tea.Cmd) {
+	if m.selectedProject.ID == 0 || m.selectedTask.ID == 0 {
+		m.error = "Select a project and task first"
+		return m, nil
+	}
+
+	// Start from the running timer's notes so they can be tweaked
+	if m.ticketInput.Value() == "" && m.activeTimer != nil {
+		m.ticketInput.SetValue(m.activeTimer.Notes)
+		m.ticketInput.CursorEnd()
+	}
+
+	m.error = ""
+	m.state = "enter_details"
+	m.ticketInput.Focus()
+	return m, textinput.Blink
+}
+
+// Handle keys while the alias prompt is open
+func (m Model) updateAliasInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		m.quitting = true
+		return m, tea.Quit
+	case "esc":
+		m.aliasInput.Blur()
+		m.state = "select_task"
+		return m, nil
+	case "enter":
+		name := strings.ToLower(strings.TrimSpace(m.aliasInput.Value()))
+		if name == "" || strings.ContainsAny(name, " \t") {
+			m.error = "Alias must be a single word"
+			return m, nil
+		}
+
+		if m.config.Aliases == nil {
+			m.config.Aliases = make(map[string]Alias)
+		}
+		m.config.Aliases[name] = Alias{
+			ProjectID:   m.selectedProject.ID,
+			TaskID:      m.selectedTask.ID,
+			ProjectName: m.selectedProject.Name,
+			TaskName:    m.selectedTask.Name,
+		}
+
+		m.error = ""
+		m.aliasInput.Blur()
+		m.state = "select_task"
+		return m, saveConfigCmd(m.config, fmt.Sprintf("Alias %q saved", name))
+	}
+
+	var cmd tea.Cmd
+	m.aliasInput, cmd = m.aliasInput.Update(msg)
+	return m, cmd
+}
+
 var docStyle = lipgloss.NewStyle().Margin(1, 2)
 
 // View function for the Bubble Tea framework
@@ -483,11 +1603,26 @@ func (m Model) View() string {
 		return "Bye!\n"
 	}
 
+	// The size is unknown until the first WindowSizeMsg
+	if m.width > 0 && (m.width < minWidth || m.height < minHeight) {
+		return "Terminal too small — please resize"
+	}
+
 	var s string
 	title := titleStyle.Render("✓ Harvest Timer TUI")
+	if subdomain := webSubdomain(m.webBase); subdomain != "" {
+		title += infoStyle.Render(" " + subdomain)
+	}
+	if m.config.safeMode() {
+		title += " " + errorStyle.Bold(true).Render("SAFE MODE")
+	}
+
+	if m.focusMode && m.activeTimer != nil {
+		return m.focusView()
+	}
 
 	if m.showHelp {
-		return docStyle.Render(title + "\n\n" + helpContent)
+		return docStyle.Render(title + "\n\n" + m.keys.helpView() + m.quickActionsHelp() + helpContent)
 	}
 
 	switch m.state {
@@ -495,12 +1630,20 @@ func (m Model) View() string {
 		s = "Loading projects...\n"
 	case "loading_tasks":
 		s = "Loading tasks...\n"
+	case "loading_search":
+		s = "Loading tasks from all projects...\n"
 	case "select_project":
 		s = m.projectList.View()
+		if len(m.projects) == 0 {
+			s = m.noProjectsView()
+		}
+	case "search_tasks":
+		s = m.searchList.View()
 	case "select_task":
 		s = fmt.Sprintf(
-			"Project: %s\n\n%s",
+			"Project: %s\n%s\n%s",
 			m.selectedProject.Name,
+			m.budgetView(m.selectedProject.ID),
 			m.taskList.View(),
 		)
 	case "enter_details":
@@ -509,24 +1652,69 @@ func (m Model) View() string {
 		actionText := "Start Timer"
 
 		if m.activeTimer != nil {
-			status = infoStyle.Render(fmt.Sprintf("\nTimer running: %s (%.2f hours)",
-				m.activeTimer.Notes, m.activeTimer.Hours))
+			status = infoStyle.Render(fmt.Sprintf("\nTimer running: %s (%s) · %s",
+				m.activeTimer.Notes, formatElapsed(m.elapsed(), m.config.HighPrecision),
+				billableLabel(m.activeTimer.Billable)))
 			actionText = "Stop Timer"
+			if m.tentative {
+				status += errorStyle.Render(" · tentative")
+				actionText = "Finalize Notes"
+			}
+		} else if preview := m.previewLine(); preview != "" {
+			status = "\n" + infoStyle.Render(preview)
 		}
 
 		s = fmt.Sprintf(
-			"Project: %s\nTask: %s\n\n%s%s\n\nPress %s to %s",
+			"Project: %s\nTask: %s\n\n%s%s%s\n\nPress %s to %s",
 			m.selectedProject.Name,
 			m.selectedTask.Name,
 			m.ticketInput.View(),
 			status,
+			m.historyView(),
 			actionKey,
 			actionText,
 		)
+	case "daily_summary", "weekly_summary":
+		s = m.summaryView() + m.bulkEditView()
+	case "invoices":
+		s = m.invoicesView()
+	case "bulk_edit":
+		s = fmt.Sprintf("%s\nEdit notes of %d entries:\n%s",
+			m.summaryView(), len(m.summarySelected), m.bulkInput.View())
+	case "settings":
+		s = m.settingsView()
+	case "confirm_off_hours":
+		s = fmt.Sprintf("Project: %s\nTask: %s\n\n%s",
+			m.selectedProject.Name, m.selectedTask.Name, offHoursPrompt(time.Now()))
+	case "resume_timer":
+		s = m.resumePrompt()
+	case "confirm_idle_stop":
+		s = m.idlePrompt()
+	case "confirm_discard":
+		s = m.discardPrompt()
+	case "confirm_delete":
+		s = m.deletePrompt()
+	case "confirm_quick_action":
+		s = m.quickActionPrompt()
+	case "confirm_tentative":
+		s = fmt.Sprintf("Project: %s\nTask: %s\n\n%s",
+			m.selectedProject.Name, m.selectedTask.Name, tentativePrompt)
+	case "assign_alias":
+		s = fmt.Sprintf(
+			"Project: %s\nTask: %s\n\nAlias name:\n%s",
+			m.selectedProject.Name,
+			m.selectedTask.Name,
+			m.aliasInput.View(),
+		)
 	case "error":
 		s = fmt.Sprintf("Error: %s\nPress q to quit.", m.error)
 	}
 
+	switch m.state {
+	case "select_project", "select_task", "enter_details":
+		s += m.todayPanelView()
+	}
+
 	if m.error != "" && m.state != "error" {
 		errorText := errorStyle.Render("Error: " + m.error)
 		s += "\n\n" + errorText
@@ -537,29 +1725,173 @@ func (m Model) View() string {
 		s += "\n\n" + successText
 	}
 
-	header := title + "\n\n"
+	header := title + "\n\n" + m.bannerView() + m.nudgeView() + m.noticeView() + m.dashboardView() + m.diagnosticsView()
+	if m.warning != "" {
+		header += errorStyle.Render("⚠ "+m.warning) + "\n\n"
+	}
 	var footer string
 
+	// Hints name the keys as remapped in the config
+	key := m.keys.label
+	back, help, quit := key("back"), key("help"), key("quit")
+
 	switch m.state {
-	case "select_project", "select_task":
-		footer = "\n\nPress ↑/↓ to navigate, / to filter, Enter to select, Esc to go back, ? for help, q to quit"
+	case "select_project":
+		footer = fmt.Sprintf("\n\nPress ↑/↓ to navigate, / to filter, %s to select, %s to go back, %s for help, %s to quit",
+			key("select"), back, help, quit)
+	case "select_task":
+		footer = fmt.Sprintf("\n\nPress ↑/↓ to navigate, / to filter, %s to select, %s to assign an alias, %s to go back, %s for help, %s to quit",
+			key("select"), key("alias"), back, help, quit)
+	case "search_tasks":
+		footer = fmt.Sprintf("\n\nPress ↑/↓ to navigate, / to filter, %s to select, %s to reload, %s to go back, %s for help, %s to quit",
+			key("select"), key("refresh"), back, help, quit)
+	case "assign_alias":
+		footer = "\n\nPress Enter to save the alias, Esc to cancel"
+	case "confirm_off_hours", "resume_timer", "confirm_tentative", "confirm_idle_stop",
+		"confirm_discard", "confirm_quick_action", "confirm_delete":
+		footer = ""
+	case "settings":
+		footer = "\n\nPress ↑/↓ to select, Enter/Space to change, s to save, Esc to discard changes"
+	case "daily_summary":
+		footer = fmt.Sprintf("\n\nPress ↑/↓ to select, %s/%s to change day, %s/%s to jump a week, %s for today, Space to mark, %s to edit notes, %s to delete, %s to copy the entry URL, %s to refresh, %s to go back, %s to quit",
+			key("prev_period"), key("next_period"), key("prev_week"), key("next_week"), key("summary_today"),
+			key("edit_notes"), key("delete"), key("copy_url"), key("refresh"), back, quit)
+	case "weekly_summary":
+		footer = fmt.Sprintf("\n\nPress ↑/↓ to select, %s/%s to change week, %s for this week, %s to edit notes, %s to delete, %s to copy the entry URL, %s to refresh, %s to go back, %s to quit",
+			key("prev_period"), key("next_period"), key("summary_today"),
+			key("edit_notes"), key("delete"), key("copy_url"), key("refresh"), back, quit)
+	case "invoices":
+		footer = fmt.Sprintf("\n\nPress ↑/↓ to select, %s to refresh, %s to go back, %s to quit", key("refresh"), back, quit)
+	case "bulk_edit":
+		footer = "\n\nPress Enter to apply, Esc to cancel"
 	case "enter_details":
-		footer = "\n\nPress Enter to start/stop timer, Esc to go back, ? for help, q to quit"
+		if m.ticketInput.Focused() {
+			footer = fmt.Sprintf("\n\nPress %s to start/stop timer, %s to leave the notes field, %s to go back",
+				key("select"), key("toggle_focus"), back)
+		} else {
+			footer = fmt.Sprintf("\n\nPress %s to start/stop timer, %s to edit notes, %s to go back, %s for help, %s to quit",
+				key("select"), key("notes"), back, help, quit)
+		}
 	default:
-		footer = "\n\nPress ? for help, q to quit"
+		footer = fmt.Sprintf("\n\nPress %s for help, %s to quit", help, quit)
 	}
 
 	return docStyle.Render(header + s + footer)
 }
 
+// Summarize what will be logged once project, task and notes are all set
+func (m Model) previewLine() string {
+	notes := strings.TrimSpace(m.ticketInput.Value())
+	if m.config.HidePreview || notes == "" || m.selectedProject.ID == 0 || m.selectedTask.ID == 0 {
+		return ""
+	}
+
+	billable := "non-billable"
+	if m.selectedTask.Billable {
+		billable = "billable"
+	}
+
+	return fmt.Sprintf("Will track: %s / %s — '%s' (%s)",
+		m.selectedProject.Name, m.selectedTask.Name, notes, billable)
+}
+
+// Trim notes and cap them at the maximum length
+func sanitizeNotes(notes string) string {
+	notes = strings.TrimSpace(notes)
+	if runes := []rune(notes); len(runes) > maxNotesLength {
+		notes = string(runes[:maxNotesLength])
+	}
+	return notes
+}
+
+// Append text to notes with the separator, leaving out the separator when
+// there are no notes yet. A separator already ending the notes, or starting
+// the text, is not repeated.
+func appendNotes(notes, text, separator string) string {
+	notes, text = strings.TrimSpace(notes), strings.TrimSpace(text)
+	if mark := strings.TrimSpace(separator); mark != "" {
+		notes = strings.TrimSpace(strings.TrimSuffix(notes, mark))
+		text = strings.TrimSpace(strings.TrimPrefix(text, mark))
+	}
+
+	switch {
+	case text == "":
+		return sanitizeNotes(notes)
+	case notes == "":
+		return sanitizeNotes(text)
+	}
+	return sanitizeNotes(notes + separator + text)
+}
+
+// Secondary line for a project list item
+func projectDetail(project Project) string {
+	detail := project.Client
+	if project.LastTracked != "" {
+		if detail != "" {
+			detail += " · "
+		}
+		detail += "last tracked " + project.LastTracked
+	}
+	return detail
+}
+
+// Fill the project list, with the estimate used for projects that have one
+func (m *Model) setProjectItems() {
+	items := make([]list.Item, len(m.projects))
+	for i, project := range m.projects {
+		detail := projectDetail(project)
+		if b, ok := m.budgets[project.ID]; ok {
+			detail += " · " + b.summary()
+		}
+		items[i] = ListItem{ID: project.ID, Name: project.Name, Detail: detail}
+	}
+	m.projectList.SetItems(items)
+}
+
+// Explain an empty project list, which is built from recent entries
+func (m Model) noProjectsView() string {
+	search, refresh := m.keys.label("search_tasks"), m.keys.label("refresh")
+	if m.projectsSkipped > 0 {
+		return fmt.Sprintf("No projects to show: all %d of your recent entries belong to projects that were\n"+
+			"deleted or archived in Harvest.\n\n"+
+			"Press %s to search the tasks of projects you're assigned to, or ask an admin to assign\n"+
+			"you to an active project. Press %s to reload.\n", m.projectsSkipped, search, refresh)
+	}
+	return fmt.Sprintf("No projects yet: the project list comes from your recent time entries, and you\n"+
+		"haven't tracked any time.\n\n"+
+		"Press %s to search the tasks of projects you're assigned to and start your first\n"+
+		"timer. Press %s to reload.\n", search, refresh)
+}
+
+// Refill the task list from the fetched tasks
+func (m *Model) setTaskItems() {
+	items := make([]list.Item, len(m.tasks))
+	for i, task := range m.tasks {
+		items[i] = ListItem{ID: task.ID, Name: task.Name, Detail: taskDetail(task)}
+	}
+	m.taskList.SetItems(items)
+}
+
+// Secondary line for a task list item
+func taskDetail(task Task) string {
+	detail := "Non-billable"
+	if task.Billable {
+		detail = "Billable"
+	}
+	if task.LastTracked != "" {
+		detail += " · last tracked " + task.LastTracked
+	}
+	return detail
+}
+
 // Command to fetch projects
 func fetchProjects(client *HarvestClient) tea.Cmd {
 	return func() tea.Msg {
-		projects, err := client.GetProjects()
+		projects, skipped, err := client.GetProjects()
 		if err != nil {
 			return errorMsg{error: err.Error()}
 		}
-		return fetchProjectsMsg{projects: projects}
+		return fetchProjectsMsg{projects: projects, skipped: skipped}
 	}
 }
 
@@ -570,7 +1902,7 @@ func fetchTasks(client *HarvestClient, projectID int) tea.Cmd {
 		if err != nil {
 			return errorMsg{error: err.Error()}
 		}
-		return fetchTasksMsg{tasks: tasks}
+		return fetchTasksMsg{projectID: projectID, tasks: tasks}
 	}
 }
 
@@ -579,7 +1911,7 @@ func startTimer(client *HarvestClient, projectID, taskID int, notes string) tea.
 	return func() tea.Msg {
 		timer, err := client.StartTimer(projectID, taskID, notes)
 		if err != nil {
-			return errorMsg{error: err.Error()}
+			return startErrorMsg{error: err.Error()}
 		}
 		return startTimerMsg{timer: timer}
 	}
@@ -596,16 +1928,99 @@ func stopTimer(client *HarvestClient, timerID int) tea.Cmd {
 	}
 }
 
+// Command to fetch a stopped entry for its logged hours. A failed fetch
+// reports no entry so the local estimate is shown instead.
+func fetchLoggedEntry(client *HarvestClient, timerID int, local time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		entry, err := client.GetTimeEntry(timerID)
+		if err != nil {
+			return loggedEntryMsg{local: local}
+		}
+		return loggedEntryMsg{entry: entry, local: local}
+	}
+}
+
+// Describe the hours logged for a stopped timer, after the account's
+// rounding when it rounds, noting when they differ from the locally
+// tracked time
+func loggedMessage(entry *TimeEntry, local time.Duration) string {
+	if entry == nil {
+		return fmt.Sprintf("Timer stopped · about %s (local estimate)", formatElapsed(local, false))
+	}
+
+	hours := entry.Hours
+	if entry.RoundedHours != nil {
+		hours = *entry.RoundedHours
+	}
+	logged := time.Duration(hours * float64(time.Hour))
+	if diff := logged - local; diff >= time.Minute || diff <= -time.Minute {
+		return fmt.Sprintf("Logged %s (rounded from %s)", formatElapsed(logged, false), formatElapsed(local, false))
+	}
+	return fmt.Sprintf("Logged %s", formatElapsed(logged, false))
+}
+
+// Command to persist the configuration file
+func saveConfigCmd(config Configuration, message string) tea.Cmd {
+	return func() tea.Msg {
+		if err := saveConfig(config); err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to save config: %v", err)}
+		}
+		return configSavedMsg{message: message}
+	}
+}
+
+// Command to fetch the running timer
+func fetchRunningTimer(client *HarvestClient) tea.Cmd {
+	return func() tea.Msg {
+		timer, err := client.GetRunningTimer()
+		if err != nil {
+			return errorMsg{error: err.Error()}
+		}
+		return runningTimerMsg{timer: timer}
+	}
+}
+
+// Command to refresh the elapsed time display
+func tickElapsed(id int, delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return tickMsg{id: id}
+	})
+}
+
+// Command to schedule the next running timer check
+func reconcileTimer(id int, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return reconcileMsg{id: id}
+	})
+}
+
+// Delay until the next display refresh. Coarse ticks are aligned to the
+// next minute boundary so the displayed minutes never lag behind.
+func nextTickDelay(elapsed time.Duration, config Configuration) time.Duration {
+	interval := config.tickInterval()
+	if config.HighPrecision {
+		return interval
+	}
+
+	untilNextMinute := time.Minute - elapsed%time.Minute
+	if untilNextMinute < interval {
+		return untilNextMinute
+	}
+	return interval
+}
+
+// Format an elapsed duration, with seconds only in high precision mode
+func formatElapsed(d time.Duration, precise bool) string {
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	if precise {
+		return fmt.Sprintf("%dh %02dm %02ds", h, m, int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh %02dm", h, m)
+}
+
 // Help content
 const helpContent = `
-KEYBOARD SHORTCUTS
-  ↑/↓          Navigate through options
-  /            Filter the list (start typing to search)
-  Enter        Select project/task or start/stop timer
-  Esc          Go back to previous screen
-  ?            Show/hide this help
-  q or Ctrl+C  Quit the application
-
 WORKFLOW
   1. Select a project
   2. Select a task
@@ -617,10 +2032,33 @@ Your Harvest timer will sync automatically with the web interface.
 `
 
 func main() {
-	// Load configuration from environment variables
-	config := Configuration{
-		AccountID:   os.Getenv("HARVEST_ACCOUNT_ID"),
-		AccessToken: os.Getenv("HARVEST_ACCESS_TOKEN"),
+	flags := flag.NewFlagSet("harvest-tui", flag.ContinueOnError)
+	color := flags.String("color", colorAuto, "use colors: auto, always or never")
+	flags.BoolVar(&diagnosticsFlag, "diagnostics", false, "show API request counts and latency")
+	flags.BoolVar(&safeModeFlag, "safe-mode", false, "disable actions that delete or overwrite entries")
+	flags.Usage = printUsage
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+	if err := applyColorMode(*color); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// Non-interactive subcommands skip the TUI entirely
+	if flags.NArg() > 0 {
+		os.Exit(runCommand(flags.Args()))
+	}
+
+	// Load preferences from the config file and credentials from environment variables
+	config, err := loadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	config.AccountID = os.Getenv("HARVEST_ACCOUNT_ID")
+	config.AccessToken = os.Getenv("HARVEST_ACCESS_TOKEN")
+	if err := applyTimezone(config.Timezone); err != nil {
+		log.Fatal(err)
 	}
 
 	// Validate configuration
@@ -628,9 +2066,11 @@ func main() {
 		log.Fatal("HARVEST_ACCOUNT_ID and HARVEST_ACCESS_TOKEN environment variables must be set")
 	}
 
-	// Create Harvest client to test connection
+	// Create Harvest client to test connection, which also tells whose
+	// entries to show
 	client := NewHarvestClient(config)
-	if err := client.TestConnection(); err != nil {
+	user, err := client.GetCurrentUser()
+	if err != nil {
 		fmt.Printf("\n⛔ ERROR: %v\n\n", err)
 		fmt.Println("Please check your Harvest API credentials and access.")
 		os.Exit(1)
@@ -638,12 +2078,28 @@ func main() {
 
 	// Initialize the model
 	model := initialModel(config)
+	model.harvestClient.userID = user.ID
+
+	// Warn when another instance could change the same timer
+	release, otherPID, err := acquireLock()
+	if err != nil {
+		log.Printf("Instance lock unavailable: %v", err)
+	}
+	defer release()
+	if otherPID != 0 {
+		model.warning = fmt.Sprintf("Another harvest-tui instance is running (PID %d); timer actions may conflict", otherPID)
+	}
 
 	// Start the program
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	options := []tea.ProgramOption{tea.WithAltScreen()}
+	if config.FocusNudge {
+		options = append(options, tea.WithReportFocus())
+	}
+	p := tea.NewProgram(model, options...)
 
 	// Run the program
 	if _, err := p.Run(); err != nil {
+		release()
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Client for a test server answering every request with body
+func newTestClient(t *testing.T, body string) *HarvestClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return NewHarvestClient(Configuration{BaseURL: server.URL + "/v2"})
+}
+
+// Model as the app builds it, with the cache and config directories in a
+// temporary directory
+func newTestModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	return initialModel(Configuration{})
+}
+
+func TestGetProjectsSkipsDeletedProjects(t *testing.T) {
+	client := newTestClient(t, `{"time_entries": [
+		{"project": {"id": 1, "name": "Website"}, "client": {"name": "Acme"}, "spent_date": "2025-03-10"},
+		{"project": null, "client": {"name": "Gone"}, "spent_date": "2025-03-11"},
+		{"client": {"name": "Missing"}, "spent_date": "2025-03-11"},
+		{"project": {"id": 0, "name": ""}, "spent_date": "2025-03-11"},
+		{"project": {"id": 1, "name": "Website"}, "client": {"name": "Acme"}, "spent_date": "2025-03-12"}
+	]}`)
+
+	projects, skipped, err := client.GetProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 1 || projects[0].ID != 1 {
+		t.Fatalf("projects = %+v, want only Website", projects)
+	}
+	if projects[0].LastTracked != "2025-03-12" {
+		t.Errorf("LastTracked = %s, want the latest date", projects[0].LastTracked)
+	}
+	if skipped != 3 {
+		t.Errorf("skipped = %d, want 3", skipped)
+	}
+}
+
+func TestGetTasksSkipsDeletedTasks(t *testing.T) {
+	client := newTestClient(t, `{"time_entries": [
+		{"task": {"id": 5, "name": "Design"}, "billable": true, "spent_date": "2025-03-10"},
+		{"task": null, "spent_date": "2025-03-11"},
+		{"task": {"id": 0, "name": ""}, "spent_date": "2025-03-11"},
+		{"task": {"id": 6, "name": "Meetings"}, "spent_date": "2025-03-11"}
+	]}`)
+
+	tasks, err := client.GetTasks(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	if len(tasks) != 2 || tasks[0].ID != 5 || tasks[1].ID != 6 {
+		t.Errorf("tasks = %+v, want Design and Meetings", tasks)
+	}
+}
+
+func TestFetchTasksIgnoresStaleProject(t *testing.T) {
+	m := newTestModel(t)
+
+	// Select project 1 and then project 2 before 1's tasks arrive
+	m.selectedProject = Project{ID: 1, Name: "Website"}
+	m.state = "loading_tasks"
+	m.selectedProject = Project{ID: 2, Name: "Mobile App"}
+
+	model, _ := m.Update(fetchTasksMsg{projectID: 2, tasks: []Task{{ID: 20, Name: "Development"}}})
+	model, _ = model.Update(fetchTasksMsg{projectID: 1, tasks: []Task{{ID: 10, Name: "Design"}}})
+	got := model.(Model)
+
+	if got.state != "select_task" {
+		t.Fatalf("state = %q, want select_task", got.state)
+	}
+	if len(got.tasks) != 1 || got.tasks[0].ID != 20 {
+		t.Errorf("tasks = %+v, want project 2's tasks despite project 1's arriving last", got.tasks)
+	}
+}
+
+func TestFetchTasksIgnoresStaleProjectWhileLoading(t *testing.T) {
+	m := newTestModel(t)
+	m.selectedProject = Project{ID: 2, Name: "Mobile App"}
+	m.state = "loading_tasks"
+
+	model, _ := m.Update(fetchTasksMsg{projectID: 1, tasks: []Task{{ID: 10, Name: "Design"}}})
+	if got := model.(Model); got.state != "loading_tasks" || len(got.tasks) != 0 {
+		t.Errorf("state %q with tasks %+v, want project 1's tasks ignored", got.state, got.tasks)
+	}
+}
+
+func TestRapidStartsCreateOneTimer(t *testing.T) {
+	var posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 5, "is_running": true, "hours": 0, "project": {"id": 1}, "task": {"id": 2}}`))
+	}))
+	defer server.Close()
+
+	m := newTestModel(t)
+	m.harvestClient = NewHarvestClient(Configuration{BaseURL: server.URL + "/v2"})
+	m.state = "enter_details"
+	m.selectedProject = Project{ID: 1, Name: "Website"}
+	m.selectedTask = Task{ID: 2, Name: "Design"}
+	m.ticketInput.SetValue("TICKET-1 - Mockups")
+
+	model, first := m.startWithinHours()
+	model, second := model.(Model).startWithinHours()
+	if first == nil {
+		t.Fatal("first start sent nothing")
+	}
+	if second != nil {
+		t.Error("second start sent while the first was pending")
+	}
+
+	msg := first()
+	if n := posts.Load(); n != 1 {
+		t.Fatalf("%d timers created, want 1", n)
+	}
+
+	model, _ = model.Update(msg)
+	got := model.(Model)
+	if got.startPending {
+		t.Error("start still pending after it was confirmed")
+	}
+	if got.activeTimer == nil || got.activeTimer.ID != 5 {
+		t.Fatalf("active timer = %+v, want 5", got.activeTimer)
+	}
+
+	// The server reports a different timer, e.g. started elsewhere
+	model, _ = got.Update(runningTimerMsg{timer: &Timer{ID: 6, ProjectID: 1, TaskID: 2, IsRunning: true}})
+	if got := model.(Model); got.activeTimer == nil || got.activeTimer.ID != 6 {
+		t.Errorf("active timer = %+v, want the server's 6", got.activeTimer)
+	}
+}
+
+func TestHelpKeepsFilteredList(t *testing.T) {
+	m := newTestModel(t)
+	m.state = "select_project"
+	m.projects = []Project{
+		{ID: 1, Name: "Dev Ops"},
+		{ID: 2, Name: "Development"},
+		{ID: 3, Name: "Mobile App"},
+		{ID: 4, Name: "Web Development"},
+	}
+	m.setProjectItems()
+	m.projectList.SetSize(80, 20)
+	m.projectList.SetFilterText("dev")
+	m.projectList.Select(2)
+
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	model, _ := m.Update(runes("?"))
+	if !model.(Model).showHelp {
+		t.Fatal("help not shown")
+	}
+
+	// Keys pressed over the help must not reach the list or its filter
+	for _, key := range []tea.KeyMsg{runes("j"), runes("k"), runes("x"), runes("/"), {Type: tea.KeyEnter}, {Type: tea.KeyDown}} {
+		model, _ = model.Update(key)
+	}
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	got := model.(Model)
+	if got.showHelp {
+		t.Fatal("esc didn't close help")
+	}
+
+	if got.state != "select_project" {
+		t.Errorf("state = %q, want select_project", got.state)
+	}
+	if got.projectList.FilterState() != list.FilterApplied || got.projectList.FilterValue() != "dev" {
+		t.Errorf("filter %v %q, want dev still applied", got.projectList.FilterState(), got.projectList.FilterValue())
+	}
+	if got.projectList.Index() != 2 || len(got.projectList.VisibleItems()) != 3 {
+		t.Errorf("selection %d of %d items, want 2 of 3", got.projectList.Index(), len(got.projectList.VisibleItems()))
+	}
+}
+
+func TestLoggedMessage(t *testing.T) {
+	hours := func(h float64) *float64 { return &h }
+	local := 88 * time.Minute
+
+	tests := []struct {
+		name  string
+		entry *TimeEntry
+		want  string
+	}{
+		{"fetch failed", nil, "Timer stopped · about 1h 28m (local estimate)"},
+		{"account rounds", &TimeEntry{Hours: 1.47, RoundedHours: hours(1.5)}, "Logged 1h 30m (rounded from 1h 28m)"},
+		{"rounding changed nothing", &TimeEntry{Hours: 1.47, RoundedHours: hours(1.47)}, "Logged 1h 28m"},
+		{"rounded hours win over hours", &TimeEntry{Hours: 1.5, RoundedHours: hours(1.47)}, "Logged 1h 28m"},
+		{"no rounding, matches local", &TimeEntry{Hours: 1.47}, "Logged 1h 28m"},
+		{"no rounding, differs from local", &TimeEntry{Hours: 1.75}, "Logged 1h 45m (rounded from 1h 28m)"},
+	}
+	for _, tt := range tests {
+		if got := loggedMessage(tt.entry, local); got != tt.want {
+			t.Errorf("%s: %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTimeEntryDecodesRoundedHours(t *testing.T) {
+	client := newTestClient(t, `{"id": 7, "hours": 1.47, "rounded_hours": 1.5}`)
+
+	entry, err := client.GetTimeEntry(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.RoundedHours == nil || *entry.RoundedHours != 1.5 {
+		t.Errorf("RoundedHours = %v, want 1.5", entry.RoundedHours)
+	}
+}
+
+func TestClearedTimerLeavesFocusMode(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  tea.Msg
+	}{
+		{"stopped", stopTimerMsg{success: true}},
+		{"discarded", discardedMsg{timerID: 5}},
+		{"stopped elsewhere", runningTimerMsg{}},
+		{"deleted", entryDeletedMsg{entry: TimeEntry{ID: 5}}},
+	}
+	for _, tt := range tests {
+		m := newTestModel(t)
+		m.activeTimer = &Timer{ID: 5}
+		m.focusMode = true
+
+		model, _ := m.Update(tt.msg)
+		got := model.(Model)
+		if got.activeTimer != nil || got.focusMode {
+			t.Errorf("%s: timer %+v, focus mode %v; want both cleared", tt.name, got.activeTimer, got.focusMode)
+		}
+	}
+}
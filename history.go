@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Past notes kept per project/task, and how many are shown under the input
+const (
+	maxHistoryNotes   = 20
+	shownHistoryNotes = 5
+)
+
+// notesHistory holds previously used notes by project/task, newest first
+type notesHistory map[string][]string
+
+// Key of a project/task pair in the notes history
+func historyKey(projectID, taskID int) string {
+	return fmt.Sprintf("%d:%d", projectID, taskID)
+}
+
+// Load the notes history, starting empty when the file is missing or corrupt
+func loadHistory() notesHistory {
+	history := make(notesHistory)
+	if !historyFile.load(&history) {
+		return make(notesHistory)
+	}
+	return history
+}
+
+// Remember notes used for a project/task, moving repeats to the front
+func (h notesHistory) add(projectID, taskID int, notes string) {
+	if notes == "" {
+		return
+	}
+
+	key := historyKey(projectID, taskID)
+	past := []string{notes}
+	for _, n := range h[key] {
+		if n != notes && len(past) < maxHistoryNotes {
+			past = append(past, n)
+		}
+	}
+	h[key] = past
+}
+
+// Past notes of the selected project/task, newest first
+func (m Model) pastNotes() []string {
+	return m.history[historyKey(m.selectedProject.ID, m.selectedTask.ID)]
+}
+
+// Move through the past notes with ↑/↓, putting the chosen one in the
+// input for editing. Returns false when the key isn't for the history.
+func (m *Model) browseHistory(key string) bool {
+	past := m.pastNotes()
+	if len(past) == 0 || m.activeTimer != nil {
+		return false
+	}
+
+	switch key {
+	case "up":
+		// Keep what was being typed to bring it back at the bottom
+		if m.historyCursor == 0 {
+			m.historyStash = m.ticketInput.Value()
+		}
+		m.historyCursor = min(m.historyCursor+1, min(len(past), shownHistoryNotes))
+	case "down":
+		if m.historyCursor == 0 {
+			return true
+		}
+		m.historyCursor--
+	default:
+		return false
+	}
+
+	// Back at the bottom restores the notes typed before browsing
+	if m.historyCursor == 0 {
+		m.ticketInput.SetValue(m.historyStash)
+		m.ticketInput.CursorEnd()
+		return true
+	}
+	m.ticketInput.SetValue(past[m.historyCursor-1])
+	m.ticketInput.CursorEnd()
+	return true
+}
+
+// Past notes listed under the notes input, with the chosen one marked
+func (m Model) historyView() string {
+	past := m.pastNotes()
+	if len(past) == 0 || m.activeTimer != nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\n" + infoStyle.Render("Previous notes (↑/↓ to reuse):"))
+	for i, notes := range past[:min(len(past), shownHistoryNotes)] {
+		line := "  " + notes
+		if i+1 == m.historyCursor {
+			line = "> " + notes
+		}
+		if m.width > 0 {
+			line = ansi.Truncate(line, m.width-docStyle.GetHorizontalFrameSize(), "…")
+		}
+		if i+1 == m.historyCursor {
+			line = successStyle.Render(line)
+		}
+		b.WriteString("\n" + line)
+	}
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+func newHistoryModel(typed string) Model {
+	m := Model{
+		selectedProject: Project{ID: 1},
+		selectedTask:    Task{ID: 2},
+		history:         notesHistory{historyKey(1, 2): {"Newest", "Older"}},
+		ticketInput:     textinput.New(),
+	}
+	m.ticketInput.SetValue(typed)
+	return m
+}
+
+func TestBrowseHistoryRestoresTypedNotes(t *testing.T) {
+	m := newHistoryModel("TICKET-9 - half typed")
+
+	steps := []struct {
+		key  string
+		want string
+	}{
+		{"up", "Newest"},
+		{"up", "Older"},
+		{"up", "Older"}, // stops at the oldest
+		{"down", "Newest"},
+		{"down", "TICKET-9 - half typed"},
+		{"down", "TICKET-9 - half typed"}, // already at the bottom
+	}
+	for i, step := range steps {
+		if !m.browseHistory(step.key) {
+			t.Fatalf("step %d: %s not handled", i, step.key)
+		}
+		if got := m.ticketInput.Value(); got != step.want {
+			t.Errorf("step %d: %s gave %q, want %q", i, step.key, got, step.want)
+		}
+	}
+}
+
+func TestBrowseHistoryDownKeepsTypedNotes(t *testing.T) {
+	m := newHistoryModel("TICKET-9 - half typed")
+
+	m.browseHistory("down")
+	if got := m.ticketInput.Value(); got != "TICKET-9 - half typed" {
+		t.Errorf("↓ at the bottom changed the notes to %q", got)
+	}
+}
+
+func TestBrowseHistoryStashesLatestTyping(t *testing.T) {
+	m := newHistoryModel("first draft")
+
+	m.browseHistory("up")
+	m.browseHistory("down")
+	m.ticketInput.SetValue("second draft")
+	m.browseHistory("up")
+	m.browseHistory("down")
+
+	if got := m.ticketInput.Value(); got != "second draft" {
+		t.Errorf("notes = %q, want the latest typing restored", got)
+	}
+}
+
+func TestBrowseHistoryWhileTimerRuns(t *testing.T) {
+	m := newHistoryModel("notes")
+	m.activeTimer = &Timer{ID: 1}
+
+	if m.browseHistory("up") {
+		t.Error("history browsed while a timer is running")
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// How often system idle time is checked while a timer runs
+const idleCheckInterval = time.Minute
+
+// Returned by systemIdleTime where idle detection isn't available
+var errIdleUnsupported = errors.New("idle detection is not supported on this system")
+
+// Result of checking how long the system has been idle
+type idleMsg struct {
+	id   int
+	idle time.Duration
+	err  error
+}
+
+// Command to check system idle time after the check interval
+func checkIdle(id int) tea.Cmd {
+	return tea.Tick(idleCheckInterval, func(time.Time) tea.Msg {
+		idle, err := systemIdleTime()
+		return idleMsg{id: id, idle: idle, err: err}
+	})
+}
+
+// Idle threshold after which the running timer is stopped, zero when off
+func (c Configuration) idleStopAfter() time.Duration {
+	return time.Duration(c.IdleStopMinutes) * time.Minute
+}
+
+// Stop the running timer when the system has been idle past the threshold,
+// asking first when configured to
+func (m Model) handleIdle(msg idleMsg) (tea.Model, tea.Cmd) {
+	// Drop checks for a previous timer
+	if msg.id != m.tickID || m.activeTimer == nil || m.config.idleStopAfter() == 0 {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		// Checked no further until the next timer starts
+		m.error = fmt.Sprintf("Idle auto-stop disabled: %v", msg.err)
+		return m, nil
+	}
+
+	if msg.idle < m.config.idleStopAfter() || m.state == "confirm_idle_stop" {
+		return m, checkIdle(m.tickID)
+	}
+
+	m.idleSince = time.Now().Add(-msg.idle)
+	if m.config.ConfirmIdleStop {
+		m.idleReturn = m.state
+		m.state = "confirm_idle_stop"
+		return m, checkIdle(m.tickID)
+	}
+
+	m.success = fmt.Sprintf("Timer stopped after %s idle", formatElapsed(msg.idle, false))
+	return m, m.stopIdle()
+}
+
+// Handle the prompt shown before stopping a timer after idle time
+func (m Model) updateIdlePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y":
+		m.state = m.idleReturn
+		if m.activeTimer == nil {
+			return m, nil
+		}
+		return m, m.stopIdle()
+	case "n", "esc":
+		// Keep the timer running, idle time included
+		m.state = m.idleReturn
+	}
+	return m, nil
+}
+
+// Prompt shown when the system has been idle past the threshold
+func (m Model) idlePrompt() string {
+	return fmt.Sprintf("You've been away since %s (%s idle).\n\n"+
+		"y = stop the timer at %s, n = keep it running",
+		m.idleSince.Format("15:04"), formatElapsed(time.Since(m.idleSince), false), m.idleSince.Format("15:04"))
+}
+
+// Command to stop the running timer after idle time. Safe mode doesn't
+// overwrite the entry's hours, so the idle time stays logged.
+func (m Model) stopIdle() tea.Cmd {
+	if m.config.safeMode() {
+		return stopTimer(m.harvestClient, m.activeTimer.ID)
+	}
+	return stopIdleTimer(m.harvestClient, m.activeTimer.ID, m.idleSince.Sub(m.timerStartedAt))
+}
+
+// Command to stop a timer and trim it to the time tracked before going idle
+func stopIdleTimer(client *HarvestClient, timerID int, tracked time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.StopTimer(timerID); err != nil {
+			return errorMsg{error: err.Error()}
+		}
+
+		hours := max(tracked.Hours(), 0)
+		if _, err := client.UpdateTimeEntry(timerID, map[string]any{"hours": hours}); err != nil {
+			return errorMsg{error: fmt.Sprintf("Timer stopped but idle time not removed: %v", err)}
+		}
+		return stopTimerMsg{success: true, tracked: tracked}
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var hidIdleTime = regexp.MustCompile(`"HIDIdleTime" = (\d+)`)
+
+// Idle time from the HID system, which ioreg reports in nanoseconds
+func systemIdleTime() (time.Duration, error) {
+	out, err := exec.Command("ioreg", "-c", "IOHIDSystem", "-d", "4").Output()
+	if err != nil {
+		return 0, err
+	}
+
+	match := hidIdleTime.FindSubmatch(out)
+	if match == nil {
+		return 0, fmt.Errorf("HIDIdleTime not found in ioreg output")
+	}
+
+	ns, err := strconv.ParseInt(string(match[1]), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ns), nil
+}
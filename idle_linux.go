@@ -0,0 +1,26 @@
+package main
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Idle time from xprintidle, which reports the X11 idle time in milliseconds
+func systemIdleTime() (time.Duration, error) {
+	if _, err := exec.LookPath("xprintidle"); err != nil {
+		return 0, errIdleUnsupported
+	}
+
+	out, err := exec.Command("xprintidle").Output()
+	if err != nil {
+		return 0, err
+	}
+
+	ms, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
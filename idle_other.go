@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !windows
+
+package main
+
+import "time"
+
+func systemIdleTime() (time.Duration, error) {
+	return 0, errIdleUnsupported
+}
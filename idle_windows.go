@@ -0,0 +1,28 @@
+package main
+
+import (
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var (
+	procGetLastInputInfo = syscall.NewLazyDLL("user32.dll").NewProc("GetLastInputInfo")
+	procGetTickCount     = syscall.NewLazyDLL("kernel32.dll").NewProc("GetTickCount")
+)
+
+type lastInputInfo struct {
+	cbSize uint32
+	dwTime uint32
+}
+
+// Idle time since the last keyboard or mouse input
+func systemIdleTime() (time.Duration, error) {
+	info := lastInputInfo{cbSize: uint32(unsafe.Sizeof(lastInputInfo{}))}
+	if ok, _, err := procGetLastInputInfo.Call(uintptr(unsafe.Pointer(&info))); ok == 0 {
+		return 0, err
+	}
+
+	now, _, _ := procGetTickCount.Call()
+	return time.Duration(uint32(now)-info.dwTime) * time.Millisecond, nil
+}
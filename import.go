@@ -0,0 +1,407 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProjectAssignment is a project the user can track time to, with the
+// tasks assigned to it
+type ProjectAssignment struct {
+	Project Project `json:"project"`
+	Client  struct {
+		Name string `json:"name"`
+	} `json:"client"`
+	TaskAssignments []struct {
+		Task     Task `json:"task"`
+		Billable bool `json:"billable"`
+	} `json:"task_assignments"`
+}
+
+// Fetch the projects and tasks assigned to the current user
+func (h *HarvestClient) GetProjectAssignments() ([]ProjectAssignment, error) {
+	var assignments []ProjectAssignment
+
+	for page := 1; ; page++ {
+		var result struct {
+			ProjectAssignments []ProjectAssignment `json:"project_assignments"`
+			NextPage           *int                `json:"next_page"`
+		}
+
+		resp, err := h.client.R().
+			SetResult(&result).
+			Get(fmt.Sprintf("/users/me/project_assignments?page=%d&per_page=%d", page, h.config.perPage()))
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.IsError() {
+			return nil, fmt.Errorf("API error: Status=%d, Body=%s", resp.StatusCode(), resp.String())
+		}
+
+		assignments = append(assignments, result.ProjectAssignments...)
+		if result.NextPage == nil {
+			return assignments, nil
+		}
+	}
+}
+
+// importRow is one time entry read from an import file
+type importRow struct {
+	line    int
+	date    string
+	project string
+	task    string
+	hours   float64
+	notes   string
+}
+
+// Read import rows from CSV with a header of date, project, task, hours
+// and optionally notes. Rows with invalid hours or dates, or without a
+// project or task, are returned as errors after the valid rows, so one bad
+// row doesn't stop the import.
+func readImportCSV(r io.Reader) ([]importRow, []error, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to read CSV header: %v", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"date", "project", "task", "hours"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("CSV is missing the %q column", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []importRow
+	var invalid []error
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, invalid, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		hours, err := parseHours(field(record, "hours"))
+		if err != nil {
+			invalid = append(invalid, fmt.Errorf("row %d: %v", line, err))
+			continue
+		}
+		project, task := field(record, "project"), field(record, "task")
+		if project == "" || task == "" {
+			invalid = append(invalid, fmt.Errorf("row %d: missing project or task", line))
+			continue
+		}
+		date := field(record, "date")
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			invalid = append(invalid, fmt.Errorf("row %d: invalid date %q, expected YYYY-MM-DD", line, date))
+			continue
+		}
+
+		rows = append(rows, importRow{
+			line:    line,
+			date:    date,
+			project: project,
+			task:    task,
+			hours:   hours,
+			notes:   sanitizeNotes(field(record, "notes")),
+		})
+	}
+}
+
+// Parse hours as decimal ("1.5") or hours and minutes ("1:30"), more than
+// zero and at most a day
+func parseHours(value string) (float64, error) {
+	var hours float64
+	if h, m, ok := strings.Cut(value, ":"); ok {
+		whole, err1 := strconv.Atoi(h)
+		minutes, err2 := strconv.Atoi(m)
+		if err1 != nil || err2 != nil || minutes < 0 || minutes >= 60 {
+			return 0, fmt.Errorf("invalid hours %q", value)
+		}
+		hours = float64(whole) + float64(minutes)/60
+	} else {
+		var err error
+		if hours, err = strconv.ParseFloat(value, 64); err != nil {
+			return 0, fmt.Errorf("invalid hours %q", value)
+		}
+	}
+
+	if hours <= 0 || hours > 24 {
+		return 0, fmt.Errorf("invalid hours %q", value)
+	}
+	return hours, nil
+}
+
+// Find an assigned project by ID or name, see resolveByName
+func matchAssignment(assignments []ProjectAssignment, query string) (ProjectAssignment, error) {
+	return resolveByName(assignments, query, false, "assigned project",
+		func(a ProjectAssignment) int { return a.Project.ID },
+		func(a ProjectAssignment) string { return a.Project.Name })
+}
+
+// Find a task assigned to a project by ID or name, see resolveByName
+func matchAssignedTask(assignment ProjectAssignment, query string) (Task, error) {
+	tasks := make([]Task, len(assignment.TaskAssignments))
+	for i, ta := range assignment.TaskAssignments {
+		tasks[i] = ta.Task
+	}
+	return resolveByName(tasks, query, false, "task",
+		func(t Task) int { return t.ID }, func(t Task) string { return t.Name })
+}
+
+// Names of the tasks assigned to a project
+func assignedTaskNames(assignment ProjectAssignment) string {
+	names := make([]string, len(assignment.TaskAssignments))
+	for i, ta := range assignment.TaskAssignments {
+		names[i] = ta.Task.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// Ask on the terminal for a task assigned to the project, returning false
+// when the row should be skipped
+func pickTask(in *bufio.Reader, assignment ProjectAssignment) (Task, bool) {
+	fmt.Printf("  Pick a task for %s (Enter to skip the row):\n", assignment.Project.Name)
+	for i, ta := range assignment.TaskAssignments {
+		fmt.Printf("    %d) %s\n", i+1, ta.Task.Name)
+	}
+	fmt.Print("  > ")
+
+	answer, _ := in.ReadString('\n')
+	n, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || n < 1 || n > len(assignment.TaskAssignments) {
+		return Task{}, false
+	}
+	return assignment.TaskAssignments[n-1].Task, true
+}
+
+// What to do with a row duplicating an existing entry
+const (
+	duplicateSkip      = "skip"
+	duplicateOverwrite = "overwrite"
+	duplicateCreate    = "create"
+)
+
+// Key of an entry for duplicate detection: same date, project, task and notes
+func duplicateKey(date string, projectID, taskID int, notes string) string {
+	return fmt.Sprintf("%s|%d|%d|%s", date, projectID, taskID, notes)
+}
+
+// Existing entries on the dates of the rows, by duplicate key. Each entry
+// is matched by at most one row.
+func existingEntries(client *HarvestClient, rows []importRow) (map[string][]TimeEntry, error) {
+	existing := make(map[string][]TimeEntry)
+	if len(rows) == 0 {
+		return existing, nil
+	}
+
+	from, to := rows[0].date, rows[0].date
+	for _, row := range rows {
+		from, to = min(from, row.date), max(to, row.date)
+	}
+	entries, err := client.GetTimeEntries(from, to, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		key := duplicateKey(entry.SpentDate, entry.Project.ID, entry.Task.ID, sanitizeNotes(entry.Notes))
+		existing[key] = append(existing[key], entry)
+	}
+	return existing, nil
+}
+
+// Ask on the terminal what to do with a duplicate row, skipping by default
+func pickDuplicateAction(in *bufio.Reader, entry TimeEntry) string {
+	fmt.Printf("  Entry %d already has this date, project, task and notes (%.2fh).\n", entry.ID, entry.Hours)
+	fmt.Print("  [s]kip, [o]verwrite its hours, or [c]reate anyway? [s] ")
+
+	answer, _ := in.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "o", "overwrite":
+		return duplicateOverwrite
+	case "c", "create":
+		return duplicateCreate
+	}
+	return duplicateSkip
+}
+
+// Whether stdin is an interactive terminal
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Import completed time entries from a CSV file
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	noInput := fs.Bool("no-input", false, "never prompt, report invalid rows instead")
+	onDuplicate := fs.String("on-duplicate", "", "what to do with rows matching an existing entry: skip, overwrite or create (default: ask, or skip without a terminal)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: harvest-tui import [--no-input] [--on-duplicate=skip|overwrite|create] FILE.csv")
+		return 2
+	}
+	switch *onDuplicate {
+	case "", duplicateSkip, duplicateOverwrite, duplicateCreate:
+	default:
+		fmt.Fprintf(os.Stderr, "--on-duplicate must be skip, overwrite or create, got %q\n", *onDuplicate)
+		return 2
+	}
+
+	file, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer file.Close()
+
+	rows, invalid, err := readImportCSV(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	for _, err := range invalid {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	config, err := commandConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if config.safeMode() && *onDuplicate == duplicateOverwrite {
+		fmt.Fprintln(os.Stderr, "--on-duplicate=overwrite: "+safeModeMessage)
+		return 2
+	}
+	client := NewHarvestClient(config)
+
+	assignments, err := client.GetProjectAssignments()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	// Only the user's own entries count as duplicates, not a teammate's
+	// with the same notes
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	client.userID = user.ID
+
+	existing, err := existingEntries(client, rows)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	interactive := !*noInput && stdinIsTerminal()
+	in := bufio.NewReader(os.Stdin)
+
+	created, overwritten, skipped, failed := 0, 0, 0, len(invalid)
+	for _, row := range rows {
+		assignment, err := matchAssignment(assignments, row.project)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "row %d: %v\n", row.line, err)
+			failed++
+			continue
+		}
+
+		// Harvest rejects tasks that aren't assigned to the project, so
+		// catch that here with a clearer message than the API error
+		task, err := matchAssignedTask(assignment, row.task)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "row %d: %v\nTasks assigned to project %q: %s\n",
+				row.line, err, assignment.Project.Name, assignedTaskNames(assignment))
+			if !interactive {
+				failed++
+				continue
+			}
+			var ok bool
+			if task, ok = pickTask(in, assignment); !ok {
+				failed++
+				continue
+			}
+		}
+
+		key := duplicateKey(row.date, assignment.Project.ID, task.ID, row.notes)
+		if matches := existing[key]; len(matches) > 0 {
+			duplicate := matches[0]
+			existing[key] = matches[1:]
+
+			fmt.Printf("row %d: duplicates entry %d (%s / %s on %s)\n",
+				row.line, duplicate.ID, assignment.Project.Name, task.Name, row.date)
+			action := *onDuplicate
+			if action == "" {
+				action = duplicateSkip
+				if interactive {
+					action = pickDuplicateAction(in, duplicate)
+				}
+			}
+			if action == duplicateOverwrite && config.safeMode() {
+				fmt.Println("  Overwriting is disabled in safe mode, skipping the row")
+				action = duplicateSkip
+			}
+
+			switch action {
+			case duplicateSkip:
+				skipped++
+				continue
+			case duplicateOverwrite:
+				if _, err := client.UpdateTimeEntry(duplicate.ID, map[string]any{"hours": row.hours}); err != nil {
+					fmt.Fprintf(os.Stderr, "row %d: %v\n", row.line, err)
+					failed++
+					continue
+				}
+				fmt.Printf("row %d: overwrote entry %d (%.2fh → %.2fh)\n", row.line, duplicate.ID, duplicate.Hours, row.hours)
+				overwritten++
+				continue
+			}
+		}
+
+		entry, err := client.CreateTimeEntry(assignment.Project.ID, task.ID, row.date, row.hours, row.notes)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "row %d: %v\n", row.line, err)
+			failed++
+			continue
+		}
+
+		fmt.Printf("row %d: created entry %d (%s / %s, %.2fh)\n",
+			row.line, entry.ID, assignment.Project.Name, task.Name, row.hours)
+		created++
+	}
+
+	fmt.Printf("Imported %d of %d rows: %d created, %d overwritten, %d duplicates skipped, %d failed\n",
+		created+overwritten, len(rows)+len(invalid), created, overwritten, skipped, failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
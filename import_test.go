@@ -0,0 +1,160 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseHours(t *testing.T) {
+	tests := []struct {
+		value string
+		want  float64
+		ok    bool
+	}{
+		{"1.5", 1.5, true},
+		{"1:30", 1.5, true},
+		{"0:15", 0.25, true},
+		{"24", 24, true},
+		{"24:00", 24, true},
+		{"0", 0, false},
+		{"0:00", 0, false},
+		{"-1", 0, false},
+		{"-1:30", 0, false},
+		{"24.5", 0, false},
+		{"25:00", 0, false},
+		{"1:60", 0, false},
+		{"1:xx", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := parseHours(tt.value)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("parseHours(%q) = %v, %v; want %v, ok %v", tt.value, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestReadImportCSVSkipsInvalidRows(t *testing.T) {
+	csv := `date,project,task,hours,notes
+2025-03-10,Website,Design,1:30,Mockups
+2025-03-10,Website,Design,25:00,Too long
+10/03/2025,Website,Design,1,Wrong date
+2025-03-11,Mobile App,Development,2,Sync
+2025-03-11,,Development,2,No project
+2025-03-11,Website, ,2,No task
+`
+	rows, invalid, err := readImportCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 2 || rows[0].line != 2 || rows[1].line != 5 {
+		t.Errorf("rows = %+v, want lines 2 and 5", rows)
+	}
+	if rows[0].hours != 1.5 {
+		t.Errorf("hours = %v, want 1.5", rows[0].hours)
+	}
+
+	if len(invalid) != 4 {
+		t.Fatalf("invalid = %v, want rows 3, 4, 6 and 7", invalid)
+	}
+	for i, want := range []string{
+		`row 3: invalid hours "25:00"`,
+		`row 4: invalid date "10/03/2025"`,
+		"row 6: missing project or task",
+		"row 7: missing project or task",
+	} {
+		if !strings.HasPrefix(invalid[i].Error(), want) {
+			t.Errorf("invalid[%d] = %q, want %q", i, invalid[i], want)
+		}
+	}
+}
+
+func TestReadImportCSVMissingColumn(t *testing.T) {
+	_, _, err := readImportCSV(strings.NewReader("date,project,hours\n2025-03-10,Website,1\n"))
+	if err == nil || !strings.Contains(err.Error(), `"task"`) {
+		t.Errorf("err = %v, want the missing task column", err)
+	}
+}
+
+func TestMatchAssignment(t *testing.T) {
+	assignment := func(id int, name string) ProjectAssignment {
+		return ProjectAssignment{Project: Project{ID: id, Name: name}}
+	}
+	assignments := []ProjectAssignment{
+		assignment(1, "Website Redesign"),
+		assignment(2, "Website"),
+		assignment(3, "Mobile App"),
+		assignment(4, "App Store"),
+	}
+
+	tests := []struct {
+		query  string
+		wantID int
+	}{
+		{"3", 3},
+		{"website", 2}, // exact name beats the substring match
+		{"mobile", 3},
+		{"app", 0},      // ambiguous
+		{"intranet", 0}, // no match
+	}
+	for _, tt := range tests {
+		got, err := matchAssignment(assignments, tt.query)
+		if tt.wantID == 0 {
+			if err == nil {
+				t.Errorf("%q matched %s, want an error", tt.query, got.Project.Name)
+			}
+			continue
+		}
+		if err != nil || got.Project.ID != tt.wantID {
+			t.Errorf("%q matched %d, %v; want %d", tt.query, got.Project.ID, err, tt.wantID)
+		}
+	}
+}
+
+func TestMatchAssignedTask(t *testing.T) {
+	var assignment ProjectAssignment
+	for _, task := range []Task{{ID: 1, Name: "Design"}, {ID: 2, Name: "Design Review"}, {ID: 3, Name: "Development"}} {
+		assignment.TaskAssignments = append(assignment.TaskAssignments, struct {
+			Task     Task `json:"task"`
+			Billable bool `json:"billable"`
+		}{Task: task})
+	}
+
+	if task, err := matchAssignedTask(assignment, "design"); err != nil || task.ID != 1 {
+		t.Errorf("design matched %d, %v; want 1", task.ID, err)
+	}
+	_, err := matchAssignedTask(assignment, "de")
+	if err == nil || !strings.Contains(err.Error(), "matches 3 tasks") {
+		t.Fatalf("ambiguous task gave %v, want the matches listed", err)
+	}
+	if strings.Contains(err.Error(), "--first") {
+		t.Errorf("error %q suggests --first, which import doesn't have", err)
+	}
+}
+
+func TestExistingEntriesOnlyTheUsers(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"time_entries": [{"id": 1, "spent_date": "2025-03-10", "project": {"id": 1}, "task": {"id": 2}, "notes": "Standup"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewHarvestClient(Configuration{BaseURL: server.URL + "/v2"})
+	client.userID = 42
+	existing, err := existingEntries(client, []importRow{{date: "2025-03-10"}, {date: "2025-03-12"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(query, "user_id=42") || !strings.Contains(query, "from=2025-03-10&to=2025-03-12") {
+		t.Errorf("query %s, want the user's entries over the rows' dates", query)
+	}
+	if len(existing[duplicateKey("2025-03-10", 1, 2, "Standup")]) != 1 {
+		t.Errorf("existing = %+v, want the entry keyed for duplicates", existing)
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Invoices fetched per page in the invoices view
+const invoicesPerPage = 50
+
+// Returned when the account or role can't read invoices
+var errInvoicesForbidden = errors.New("invoices are not available for this account")
+
+// Invoice is a Harvest invoice with its line items
+type Invoice struct {
+	ID        int     `json:"id"`
+	Number    string  `json:"number"`
+	Amount    float64 `json:"amount"`
+	DueAmount float64 `json:"due_amount"`
+	Currency  string  `json:"currency"`
+	State     string  `json:"state"`
+	IssueDate string  `json:"issue_date"`
+	Client    struct {
+		Name string `json:"name"`
+	} `json:"client"`
+	LineItems []InvoiceLineItem `json:"line_items"`
+}
+
+// InvoiceLineItem is one billed line of an invoice
+type InvoiceLineItem struct {
+	Kind        string  `json:"kind"`
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+	Project     *struct {
+		Name string `json:"name"`
+	} `json:"project"`
+}
+
+// A page of invoices for the invoices view
+type invoicesMsg struct {
+	page     int
+	invoices []Invoice
+	nextPage int
+	err      error
+}
+
+// Fetch one page of invoices, newest first. Returns the next page number,
+// or zero on the last page.
+func (h *HarvestClient) GetInvoices(page int) ([]Invoice, int, error) {
+	var result struct {
+		Invoices []Invoice `json:"invoices"`
+		NextPage *int      `json:"next_page"`
+	}
+
+	resp, err := h.client.R().
+		SetResult(&result).
+		Get(fmt.Sprintf("/invoices?page=%d&per_page=%d", page, invoicesPerPage))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if resp.StatusCode() == http.StatusForbidden {
+		return nil, 0, errInvoicesForbidden
+	}
+	if resp.IsError() {
+		return nil, 0, fmt.Errorf("API error: Status=%d, Body=%s", resp.StatusCode(), resp.String())
+	}
+
+	next := 0
+	if result.NextPage != nil {
+		next = *result.NextPage
+	}
+	return result.Invoices, next, nil
+}
+
+// Command to fetch a page of invoices
+func fetchInvoices(client *HarvestClient, page int) tea.Cmd {
+	return func() tea.Msg {
+		invoices, next, err := client.GetInvoices(page)
+		return invoicesMsg{page: page, invoices: invoices, nextPage: next, err: err}
+	}
+}
+
+// Open the invoices view, returning to the current screen on Esc
+func (m Model) openInvoices() (tea.Model, tea.Cmd) {
+	if m.invoicesDenied {
+		return m, nil
+	}
+
+	m.invoicesReturn = m.state
+	m.state = "invoices"
+	m.invoices = nil
+	m.invoiceCursor = 0
+	m.invoiceNextPage = 0
+	m.error = ""
+	m.success = ""
+	return m, fetchInvoices(m.harvestClient, 1)
+}
+
+// Add a fetched page, hiding the view for good when access is denied
+func (m Model) handleInvoices(msg invoicesMsg) (tea.Model, tea.Cmd) {
+	if errors.Is(msg.err, errInvoicesForbidden) {
+		m.invoicesDenied = true
+		if m.state == "invoices" {
+			m.state = m.invoicesReturn
+		}
+		m.error = "Invoices aren't available for your account or role"
+		return m, nil
+	}
+	if msg.err != nil {
+		m.error = msg.err.Error()
+		return m, nil
+	}
+
+	if msg.page == 1 {
+		m.invoices = msg.invoices
+	} else {
+		m.invoices = append(m.invoices, msg.invoices...)
+	}
+	m.invoiceNextPage = msg.nextPage
+	return m, nil
+}
+
+// Handle keys in the invoices view, loading the next page when moving past
+// the last loaded invoice
+func (m Model) updateInvoices(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.invoiceCursor > 0 {
+			m.invoiceCursor--
+		}
+		return m, nil
+	case "down", "j":
+		if m.invoiceCursor < len(m.invoices)-1 {
+			m.invoiceCursor++
+		}
+		if m.invoiceCursor == len(m.invoices)-1 && m.invoiceNextPage != 0 {
+			page := m.invoiceNextPage
+			m.invoiceNextPage = 0
+			return m, fetchInvoices(m.harvestClient, page)
+		}
+		return m, nil
+	}
+
+	switch m.keys.action(msg.String()) {
+	case "back":
+		m.state = m.invoicesReturn
+	case "refresh":
+		m.invoices = nil
+		m.invoiceCursor = 0
+		m.invoiceNextPage = 0
+		return m, fetchInvoices(m.harvestClient, 1)
+	}
+	return m, nil
+}
+
+// Hours and count of cached time entries billed on an invoice
+func (m Model) invoicedEntries(invoiceID int) (int, float64) {
+	var count int
+	var hours float64
+	for _, cache := range m.entryCache {
+		for _, entry := range cache.entries {
+			if entry.Invoice != nil && entry.Invoice.ID == invoiceID {
+				count++
+				hours += entry.Hours
+			}
+		}
+	}
+	return count, hours
+}
+
+// Render the invoice list with the line items of the selected invoice
+func (m Model) invoicesView() string {
+	var b strings.Builder
+	b.WriteString("Invoices\n\n")
+
+	if m.invoices == nil {
+		b.WriteString("Loading invoices...\n")
+		return b.String()
+	}
+	if len(m.invoices) == 0 {
+		b.WriteString(infoStyle.Render("No invoices yet") + "\n")
+		return b.String()
+	}
+
+	width := m.width - docStyle.GetHorizontalFrameSize()
+	for i, inv := range m.invoices {
+		cursor := "  "
+		if i == m.invoiceCursor {
+			cursor = "> "
+		}
+
+		line := fmt.Sprintf("%s%-8s %s  %-8s %10.2f %s  %s",
+			cursor, inv.Number, inv.IssueDate, inv.State, inv.Amount, inv.Currency, inv.Client.Name)
+		if inv.DueAmount > 0 && inv.DueAmount != inv.Amount {
+			line += fmt.Sprintf(" (%.2f due)", inv.DueAmount)
+		}
+		if m.width > 0 {
+			line = ansi.Truncate(line, width, "…")
+		}
+		if i == m.invoiceCursor {
+			line = successStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if m.invoiceNextPage != 0 {
+		b.WriteString(infoStyle.Render("  ↓ for more") + "\n")
+	}
+
+	inv := m.invoices[m.invoiceCursor]
+	fmt.Fprintf(&b, "\nInvoice %s · %d line items\n", inv.Number, len(inv.LineItems))
+	for _, item := range inv.LineItems {
+		project := ""
+		if item.Project != nil {
+			project = item.Project.Name + " · "
+		}
+		line := fmt.Sprintf("  %s%s · %.2f × %.2f = %.2f",
+			project, item.Description, item.Quantity, item.UnitPrice, item.Amount)
+		if m.width > 0 {
+			line = ansi.Truncate(line, width, "…")
+		}
+		b.WriteString(infoStyle.Render(line) + "\n")
+	}
+
+	// Only entries already loaded by the summaries can be matched
+	if count, hours := m.invoicedEntries(inv.ID); count > 0 {
+		fmt.Fprintf(&b, "\nYour loaded entries on this invoice: %d (%.2fh)\n", count, hours)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// keyAction is a shortcut that can be remapped in the config file
+type keyAction struct {
+	name     string
+	help     string
+	defaults []string
+}
+
+// Remappable actions and their default keys, in help order
+var keyActions = []keyAction{
+	{"select", "Select project/task or start/stop timer", []string{"enter"}},
+	{"alias", "Assign an alias to the highlighted task", []string{"a"}},
+	{"search_tasks", "Search tasks across all projects", []string{"s"}},
+	{"swap_project", "Swap to the most recently used other project", []string{"p"}},
+	{"notes", "Jump to the notes field for the current project/task", []string{"n"}},
+	{"toggle_billable", "Toggle billable for the running timer", []string{"b"}},
+	{"tentative", "Mark/unmark the running timer as tentative", []string{"m"}},
+	{"focus_mode", "Show only the running timer's elapsed time", []string{"f"}},
+	{"toggle_today", "Show/hide today's entries", []string{"t"}},
+	{"daily_summary", "Open the daily summary", []string{"d"}},
+	{"weekly_summary", "Open the weekly summary", []string{"w"}},
+	{"invoices", "Open the invoices view", []string{"i"}},
+	{"settings", "Open the settings screen", []string{","}},
+	{"edit_notes", "Edit the notes of the marked or highlighted entries in a summary", []string{"e"}},
+	{"delete", "Delete the highlighted summary entry", []string{"x"}},
+	{"filter_project", "Show one project at a time in the weekly summary", []string{"P"}},
+	{"next_missing", "Jump to the next summary entry missing notes", []string{"!"}},
+	{"copy_url", "Copy the Harvest URL of the running timer or selected entry", []string{"y"}},
+	{"refresh", "Refresh the summary", []string{"r"}},
+	{"external_editor", "Write the notes in $EDITOR", []string{"ctrl+g"}},
+	{"toggle_focus", "Leave or re-enter the notes field", []string{"tab"}},
+	{"back", "Go back to previous screen", []string{"esc"}},
+	{"help", "Show/hide this help", []string{"?"}},
+	{"quit", "Quit the application (Ctrl+C always quits)", []string{"q"}},
+}
+
+// Remappable actions of the daily and weekly summaries. Their keys only
+// need to be unique among themselves: in a summary, keys of the actions
+// above that work there come first, and any other key, such as t for
+// toggle_today, goes to these.
+var summaryKeyActions = []keyAction{
+	{"prev_period", "Move the summary back a day or week", []string{"left", "h"}},
+	{"next_period", "Move the summary forward a day or week", []string{"right", "l"}},
+	{"prev_week", "Jump the daily summary back a week", []string{"shift+left"}},
+	{"next_week", "Jump the daily summary forward a week", []string{"shift+right"}},
+	{"summary_today", "Return the summary to today or this week", []string{"t"}},
+}
+
+// keyMap resolves pressed keys to action names
+type keyMap struct {
+	actions  map[string]string   // key -> action
+	summary  map[string]string   // key -> summary action
+	bindings map[string][]string // action -> keys
+}
+
+// Build the effective key map from the defaults and config overrides, which
+// replace all default keys of an action
+func newKeyMap(overrides map[string][]string) (keyMap, error) {
+	k := keyMap{
+		actions:  make(map[string]string),
+		summary:  make(map[string]string),
+		bindings: make(map[string][]string),
+	}
+
+	known := make(map[string]bool)
+	for _, a := range slices.Concat(keyActions, summaryKeyActions) {
+		known[a.name] = true
+		k.bindings[a.name] = a.defaults
+	}
+
+	for name, keys := range overrides {
+		if !known[name] {
+			return k, fmt.Errorf("keys: unknown action %q", name)
+		}
+		k.bindings[name] = keys
+	}
+
+	if err := k.bind(k.actions, keyActions); err != nil {
+		return k, err
+	}
+	if err := k.bind(k.summary, summaryKeyActions); err != nil {
+		return k, err
+	}
+
+	k.actions["ctrl+c"] = "quit"
+	return k, nil
+}
+
+// Map the keys of a table of actions, refusing a key bound twice
+func (k keyMap) bind(actions map[string]string, table []keyAction) error {
+	for _, a := range table {
+		for _, key := range k.bindings[a.name] {
+			if other, taken := actions[key]; taken {
+				return fmt.Errorf("keys: %q is bound to both %s and %s", key, other, a.name)
+			}
+			actions[key] = a.name
+		}
+	}
+	return nil
+}
+
+// Action bound to a key, or "" when the key is unbound
+func (k keyMap) action(key string) string {
+	return k.actions[key]
+}
+
+// Summary action bound to a key, or "" when the key is unbound there
+func (k keyMap) summaryAction(key string) string {
+	return k.summary[key]
+}
+
+// Human-readable keys of an action, e.g. "q or ctrl+c"
+func (k keyMap) label(action string) string {
+	keys := append([]string(nil), k.bindings[action]...)
+	if action == "quit" {
+		keys = append(keys, "ctrl+c")
+	}
+	return strings.Join(keys, " or ")
+}
+
+// Keyboard shortcut section of the help screen
+func (k keyMap) helpView() string {
+	var b strings.Builder
+	b.WriteString("KEYBOARD SHORTCUTS\n")
+	b.WriteString("  ↑/↓          Navigate through options\n")
+	b.WriteString("  /            Filter the list (start typing to search)\n")
+	b.WriteString("  1-9          Pick one of the first nine list items\n")
+	for _, a := range keyActions {
+		fmt.Fprintf(&b, "  %-12s %s\n", k.label(a.name), a.help)
+	}
+	b.WriteString("\nIN THE SUMMARIES\n")
+	for _, a := range summaryKeyActions {
+		fmt.Fprintf(&b, "  %-12s %s\n", k.label(a.name), a.help)
+	}
+	return b.String()
+}
+
+// Effective bindings sorted by action, for the keys command
+func (k keyMap) table() []keyBinding {
+	table := make([]keyBinding, 0, len(keyActions)+len(summaryKeyActions))
+	for _, a := range slices.Concat(keyActions, summaryKeyActions) {
+		keys := append([]string(nil), k.bindings[a.name]...)
+		if a.name == "quit" {
+			keys = append(keys, "ctrl+c")
+		}
+		table = append(table, keyBinding{Action: a.name, Keys: keys, Description: a.help})
+	}
+	sort.Slice(table, func(i, j int) bool { return table[i].Action < table[j].Action })
+	return table
+}
+
+// keyBinding is one row of the keys command output
+type keyBinding struct {
+	Action      string   `json:"action"`
+	Keys        []string `json:"keys"`
+	Description string   `json:"description"`
+}
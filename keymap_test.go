@@ -0,0 +1,104 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSummaryKeysShareKeysWithGlobalActions(t *testing.T) {
+	keys, err := newKeyMap(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys.action("t") != "toggle_today" || keys.summaryAction("t") != "summary_today" {
+		t.Errorf("t = %q and %q in summaries, want toggle_today and summary_today",
+			keys.action("t"), keys.summaryAction("t"))
+	}
+}
+
+func TestSummaryKeyCollision(t *testing.T) {
+	_, err := newKeyMap(map[string][]string{"next_period": {"left"}})
+	if err == nil || !strings.Contains(err.Error(), "prev_period and next_period") {
+		t.Errorf("err = %v, want left bound twice in the summaries", err)
+	}
+}
+
+func TestRemappedSummaryKeys(t *testing.T) {
+	keys, err := newKeyMap(map[string][]string{
+		"prev_period":   {"["},
+		"next_period":   {"]"},
+		"summary_today": {"."},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{
+		keys:        keys,
+		state:       "daily_summary",
+		summaryDate: "2025-03-10",
+		entryCache:  make(map[string]*entryCache),
+	}
+	press := func(m Model, key string) Model {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		if key == "left" {
+			msg = tea.KeyMsg{Type: tea.KeyLeft}
+		}
+		model, _ := m.updateSummary(msg)
+		return model.(Model)
+	}
+
+	if m = press(m, "["); m.summaryDate != "2025-03-09" {
+		t.Errorf("[ moved to %s, want 2025-03-09", m.summaryDate)
+	}
+	if m = press(m, "left"); m.summaryDate != "2025-03-09" {
+		t.Errorf("left still moves the summary to %s after remapping", m.summaryDate)
+	}
+	if m = press(m, "]"); m.summaryDate != "2025-03-10" {
+		t.Errorf("] moved to %s, want 2025-03-10", m.summaryDate)
+	}
+	if m = press(m, "."); m.summaryDate != time.Now().Format("2006-01-02") {
+		t.Errorf(". moved to %s, want today", m.summaryDate)
+	}
+}
+
+func TestFooterShowsRemappedKeys(t *testing.T) {
+	m := newTestModel(t)
+	keys, err := newKeyMap(map[string][]string{"back": {"backspace"}, "prev_period": {"["}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.keys = keys
+	m.width, m.height = 300, 40
+
+	m.state = "select_task"
+	if view := m.View(); !strings.Contains(view, "backspace to go back") || strings.Contains(view, "esc to go back") {
+		t.Errorf("task list footer doesn't show the remapped back key:\n%s", view)
+	}
+
+	m.state = "daily_summary"
+	if view := m.View(); !strings.Contains(view, "[/right or l to change day") {
+		t.Errorf("summary footer doesn't show the remapped period key:\n%s", view)
+	}
+}
+
+func TestSummaryActionsBeatPeriodKeys(t *testing.T) {
+	keys, err := newKeyMap(map[string][]string{"edit_notes": {"h"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newTestModel(t)
+	m.keys = keys
+	m.state = "daily_summary"
+	m.summaryDate = "2025-03-10"
+	m.summaryEntries = []TimeEntry{{ID: 1}}
+
+	model, _ := m.updateSummary(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	got := model.(Model)
+	if got.state != "bulk_edit" || got.summaryDate != "2025-03-10" {
+		t.Errorf("h remapped to edit_notes gave state %q on %s, want bulk_edit on the same day", got.state, got.summaryDate)
+	}
+}
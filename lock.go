@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Get the path of the lock file marking a running TUI instance
+func lockPath() (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "harvest-tui.lock"), nil
+}
+
+// Take the instance lock. When another live instance holds it, its PID is
+// returned and the lock is left alone. A lock left by a crashed instance
+// is reclaimed. The release function is safe to call either way.
+func acquireLock() (release func(), otherPID int, err error) {
+	release = func() {}
+
+	path, err := lockPath()
+	if err != nil {
+		return release, 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return release, 0, err
+	}
+
+	pid := os.Getpid()
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err == nil {
+			file.WriteString(strconv.Itoa(pid))
+			file.Close()
+			return func() { releaseLock(path, pid) }, 0, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return release, 0, err
+		}
+
+		holder, err := lockHolder(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return release, 0, err
+		}
+		if holder != 0 && holder != pid && processAlive(holder) {
+			return release, holder, nil
+		}
+
+		// Stale or unreadable, take it over
+		os.Remove(path)
+	}
+
+	return release, 0, errors.New("could not take the instance lock")
+}
+
+// How long a lock file may stay without a PID before it counts as left
+// by a crashed instance, and how often to check for the PID meanwhile
+const (
+	lockWriteGrace = 3 * time.Second
+	lockPollDelay  = 50 * time.Millisecond
+)
+
+// PID in the lock file, 0 when it has none. A new lock file is created
+// before its owner writes the PID, so a young file without one is waited
+// on rather than taken for stale.
+func lockHolder(path string) (int, error) {
+	for {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 0, err
+		}
+		if holder, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil {
+			return holder, nil
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return 0, err
+		}
+		if time.Since(info.ModTime()) >= lockWriteGrace {
+			return 0, nil
+		}
+		time.Sleep(lockPollDelay)
+	}
+}
+
+// Remove the lock file if it still belongs to this process
+func releaseLock(path string, pid int) {
+	data, err := os.ReadFile(path)
+	if err == nil && strings.TrimSpace(string(data)) == strconv.Itoa(pid) {
+		os.Remove(path)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Lock path in a temporary config directory
+func newTestLockPath(t *testing.T) string {
+	t.Helper()
+	t.Setenv("HARVEST_TUI_CONFIG", filepath.Join(t.TempDir(), "config.json"))
+	path, err := lockPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLockWaitsForOwnerToWritePID(t *testing.T) {
+	path := newTestLockPath(t)
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The owner, here the test's parent process, writes its PID shortly
+	// after creating the file
+	owner := os.Getppid()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		os.WriteFile(path, []byte(strconv.Itoa(owner)), 0o600)
+	}()
+
+	release, otherPID, err := acquireLock()
+	defer release()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otherPID != owner {
+		t.Errorf("other PID = %d, want the owner %d", otherPID, owner)
+	}
+}
+
+func TestLockReclaimsOldEmptyFile(t *testing.T) {
+	path := newTestLockPath(t)
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-lockWriteGrace)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	release, otherPID, err := acquireLock()
+	if err != nil || otherPID != 0 {
+		t.Fatalf("acquireLock = %d, %v; want the lock", otherPID, err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != strconv.Itoa(os.Getpid()) {
+		t.Errorf("lock file holds %q, want this process", data)
+	}
+	release()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("lock file left after release")
+	}
+}
@@ -0,0 +1,14 @@
+package main
+
+import "os"
+
+// Whether a process with the PID exists. On Windows FindProcess opens the
+// process, which fails once it has exited.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	p.Release()
+	return true
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The running timer's entry was deleted instead of logged. Switched is set
+// for a timer stopped by starting another one.
+type discardedMsg struct {
+	timerID  int
+	switched bool
+}
+
+// Shortest timer worth logging, zero to log everything
+func (c Configuration) minimumDuration() time.Duration {
+	return time.Duration(c.MinimumMinutes) * time.Minute
+}
+
+// Shortest timer kept when switching to another one, zero to keep all
+func (c Configuration) switchGrace() time.Duration {
+	return time.Duration(c.SwitchGraceSeconds) * time.Second
+}
+
+// Command to delete the previous timer when starting next stopped it within
+// the switch grace period, noting it in the start message
+func (m *Model) discardSwitched(next *Timer) tea.Cmd {
+	previous, elapsed := m.activeTimer, m.elapsed()
+	if previous == nil || previous.ID == next.ID || elapsed >= m.config.switchGrace() || m.config.safeMode() {
+		return nil
+	}
+
+	m.success += fmt.Sprintf(" (previous timer discarded after %s, under the %s grace period)",
+		elapsed.Round(time.Second), m.config.switchGrace())
+
+	client, id := m.harvestClient, previous.ID
+	return func() tea.Msg {
+		if err := client.DeleteTimeEntry(id); err != nil {
+			return errorMsg{error: err.Error()}
+		}
+		return discardedMsg{timerID: id, switched: true}
+	}
+}
+
+// Stop the running timer, first asking whether to discard it when it ran
+// for less than the configured minimum. Safe mode never discards.
+func (m Model) stopAboveMinimum() (tea.Model, tea.Cmd) {
+	if m.activeTimer == nil {
+		return m, nil
+	}
+
+	if m.elapsed() < m.config.minimumDuration() && !m.config.safeMode() {
+		m.discardReturn = m.state
+		m.state = "confirm_discard"
+		return m, nil
+	}
+
+	return m, stopTimer(m.harvestClient, m.activeTimer.ID)
+}
+
+// Handle the prompt for timers shorter than the minimum
+func (m Model) updateDiscardPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.activeTimer == nil {
+		m.state = m.discardReturn
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "d":
+		m.state = m.discardReturn
+		return m, discardTimer(m.harvestClient, m.activeTimer.ID)
+	case "l":
+		m.state = m.discardReturn
+		return m, stopTimer(m.harvestClient, m.activeTimer.ID)
+	case "esc":
+		// Keep the timer running
+		m.state = m.discardReturn
+	}
+	return m, nil
+}
+
+// Command to delete the running timer's entry instead of logging it
+func discardTimer(client *HarvestClient, timerID int) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.DeleteTimeEntry(timerID); err != nil {
+			return errorMsg{error: err.Error()}
+		}
+		return discardedMsg{timerID: timerID}
+	}
+}
+
+// Prompt shown when stopping a timer shorter than the minimum
+func (m Model) discardPrompt() string {
+	return fmt.Sprintf("This timer has only run %s, less than your %s minimum.\n\n"+
+		"Discarding deletes the entry from Harvest, so no time is logged and its notes are lost.\n\n"+
+		"d = discard the entry, l = log it anyway, Esc = keep the timer running",
+		formatElapsed(m.elapsed(), true), formatElapsed(m.config.minimumDuration(), false))
+}
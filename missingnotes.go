@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Whether entries for a task must have notes. Tasks listed as optional,
+// such as meetings, are never flagged.
+func (c Configuration) notesRequired(task Task) bool {
+	if !c.RequireNotes {
+		return false
+	}
+	for _, name := range c.NotesOptionalTasks {
+		if strings.EqualFold(name, task.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// Whether an entry still needs notes before the timesheet is submitted
+func (m Model) missingNotes(entry TimeEntry) bool {
+	return strings.TrimSpace(entry.Notes) == "" && m.config.notesRequired(entry.Task)
+}
+
+// Number of summary entries missing notes
+func (m Model) missingNotesCount() int {
+	n := 0
+	for _, entry := range m.summaryEntries {
+		if m.missingNotes(entry) {
+			n++
+		}
+	}
+	return n
+}
+
+// Move the summary cursor to the next entry missing notes, wrapping around
+func (m *Model) nextMissingNotes() {
+	for i := 1; i <= len(m.summaryEntries); i++ {
+		index := (m.summaryCursor + i) % len(m.summaryEntries)
+		if m.missingNotes(m.summaryEntries[index]) {
+			m.summaryCursor = index
+			return
+		}
+	}
+}
+
+// Warning line above the summary entries, empty when nothing is missing
+func (m Model) missingNotesView() string {
+	n := m.missingNotesCount()
+	if n == 0 {
+		return ""
+	}
+	entries := "entries"
+	if n == 1 {
+		entries = "entry"
+	}
+	return errorStyle.Render(fmt.Sprintf("! %d %s missing notes (%s to jump, %s to edit)",
+		n, entries, m.keys.label("next_missing"), m.keys.label("edit_notes"))) + "\n\n"
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// How long the nudge stays up, and the default gap between nudges
+const (
+	nudgeDuration        = 10 * time.Second
+	defaultNudgeInterval = 30 * time.Minute
+)
+
+type clearNudgeMsg struct{ id int }
+
+// Least time between two nudges
+func (c Configuration) nudgeInterval() time.Duration {
+	if c.FocusNudgeMinutes > 0 {
+		return time.Duration(c.FocusNudgeMinutes) * time.Minute
+	}
+	return defaultNudgeInterval
+}
+
+// Nudge to start a timer when the terminal regains focus with none running
+// during working hours. Terminals that don't report focus never send the
+// message, so the nudge simply never shows there.
+func (m *Model) nudgeOnFocus(now time.Time) tea.Cmd {
+	if !m.config.FocusNudge || m.activeTimer != nil || m.startPending {
+		return nil
+	}
+	switch m.state {
+	case "select_project", "select_task", "enter_details":
+	default:
+		return nil
+	}
+	if !m.config.WorkHours.contains(now) || now.Sub(m.lastNudge) < m.config.nudgeInterval() {
+		return nil
+	}
+
+	m.lastNudge = now
+	m.nudgeID++
+	m.nudge = true
+	m.resizeLists()
+	id := m.nudgeID
+	return tea.Tick(nudgeDuration, func(time.Time) tea.Msg {
+		return clearNudgeMsg{id: id}
+	})
+}
+
+// Nudge line under the title while shown
+func (m Model) nudgeView() string {
+	if !m.nudge {
+		return ""
+	}
+	return infoStyle.Bold(true).Render("⏱ No timer running — start one? (any key to dismiss)") + "\n\n"
+}
+
+// Lines the nudge takes, including the blank line after it
+func (m Model) nudgeHeight() int {
+	if !m.nudge {
+		return 0
+	}
+	return 2
+}
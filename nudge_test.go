@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNudgeKeepsFooterOnScreen(t *testing.T) {
+	m := newTestModel(t)
+	m.state = "select_project"
+	m.config.FocusNudge = true
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	m = model.(Model)
+	height := m.projectList.Height()
+
+	if m.nudgeOnFocus(mondayAt(10, 0)) == nil {
+		t.Fatal("no nudge")
+	}
+	if got := m.projectList.Height(); got != height-m.nudgeHeight() {
+		t.Errorf("list height %d with the nudge, want %d", got, height-m.nudgeHeight())
+	}
+
+	model, _ = m.Update(clearNudgeMsg{id: m.nudgeID})
+	if got := model.(Model).projectList.Height(); got != height {
+		t.Errorf("list height %d after the nudge, want %d", got, height)
+	}
+}
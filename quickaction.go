@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// QuickAction starts a timer for an alias with template notes from a
+// single key, normally a function key
+type QuickAction struct {
+	Alias   string `json:"alias"`
+	Notes   string `json:"notes,omitempty"`
+	Confirm bool   `json:"confirm,omitempty"`
+}
+
+// Check quick actions against the aliases and the key map
+func validateQuickActions(actions map[string]QuickAction, aliases map[string]Alias, keys keyMap) error {
+	for key, action := range actions {
+		if _, ok := aliases[action.Alias]; !ok {
+			return fmt.Errorf("quick_actions: %s uses unknown alias %q", key, action.Alias)
+		}
+		if bound := keys.action(key); bound != "" {
+			return fmt.Errorf("quick_actions: %s is already bound to %q", key, bound)
+		}
+		if _, ok := quickSelectIndex(key); ok {
+			return fmt.Errorf("quick_actions: %s is used for quick-select", key)
+		}
+	}
+	return nil
+}
+
+// Start the timer of the quick action bound to a key. Returns false when
+// no quick action is bound to it.
+func (m Model) runQuickAction(key string) (tea.Model, tea.Cmd, bool) {
+	action, ok := m.config.QuickActions[key]
+	if !ok {
+		return m, nil, false
+	}
+
+	switch m.state {
+	case "select_project", "select_task", "enter_details":
+	default:
+		return m, nil, true
+	}
+
+	alias := m.config.Aliases[action.Alias]
+	m.selectedProject = Project{ID: alias.ProjectID, Name: alias.ProjectName}
+	m.selectedTask = Task{ID: alias.TaskID, Name: alias.TaskName}
+	m.ticketInput.SetValue(action.Notes)
+	m.ticketInput.Blur()
+	m.error = ""
+	m.success = ""
+	m.state = "enter_details"
+
+	// Preview the steps once the project and task are known to still exist
+	if action.Confirm {
+		m.state = "confirm_quick_action"
+		m.quickPreview = quickPreview{key: key, checking: true}
+		return m, fetchSearchIndex(m.harvestClient), true
+	}
+
+	model, cmd := m.startQuickAction()
+	return model, cmd, true
+}
+
+// Start the quick action's timer. Harvest stops any other running timer
+// when a new one starts.
+func (m Model) startQuickAction() (tea.Model, tea.Cmd) {
+	m.state = "enter_details"
+	if m.activeTimer != nil && m.activeTimer.ProjectID == m.selectedProject.ID &&
+		m.activeTimer.TaskID == m.selectedTask.ID && m.activeTimer.Notes == m.ticketInput.Value() {
+		m.success = "That timer is already running"
+		return m, nil
+	}
+	return m.startWithinHours()
+}
+
+// quickPreview tracks checking a quick action's project and task before
+// its steps are confirmed
+type quickPreview struct {
+	key        string
+	checking   bool
+	failure    string // step that would fail, which blocks running it
+	unverified string // why the check couldn't be done
+}
+
+// Resolve the quick action's project and task against the assigned ones,
+// picking up renames and noting the step that would fail when either is gone
+func (m *Model) checkQuickAction(tasks []searchTask) {
+	m.quickPreview.checking = false
+
+	project, task := m.selectedProject, m.selectedTask
+	projectFound := false
+	for _, t := range tasks {
+		if t.project.ID != project.ID {
+			continue
+		}
+		projectFound = true
+		m.selectedProject = t.project
+		if t.task.ID == task.ID {
+			m.selectedTask = t.task
+			return
+		}
+	}
+
+	if !projectFound {
+		m.quickPreview.failure = fmt.Sprintf("Step 1 would fail: project %s (#%d) no longer exists or you're no longer assigned to it",
+			project.Name, project.ID)
+		return
+	}
+	m.quickPreview.failure = fmt.Sprintf("Step 2 would fail: task %s (#%d) is no longer assigned on %s",
+		task.Name, task.ID, m.selectedProject.Name)
+}
+
+// Handle the preview shown before a quick action starts its timer
+func (m Model) updateQuickActionPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		if m.quickPreview.checking || m.quickPreview.failure != "" {
+			return m, nil
+		}
+		return m.startQuickAction()
+	case "n", "esc":
+		m.error = m.quickPreview.failure
+		m.state = "enter_details"
+	}
+	return m, nil
+}
+
+// Ordered steps the quick action will perform, with names resolved
+func (m Model) quickActionPrompt() string {
+	project := m.selectedProject.Name
+	if m.selectedProject.Client != "" {
+		project += " (" + m.selectedProject.Client + ")"
+	}
+	notes := "no notes"
+	if m.ticketInput.Value() != "" {
+		notes = fmt.Sprintf("notes %q", m.ticketInput.Value())
+	}
+
+	steps := []string{
+		"Select project " + project,
+		"Select task " + m.selectedTask.Name,
+		"Start a timer with " + notes,
+	}
+	if t := m.activeTimer; t != nil {
+		steps = append(steps, fmt.Sprintf("Harvest stops the running timer on %s / %s", t.ProjectName, t.TaskName))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Quick action %s will:\n\n", m.quickPreview.key)
+	for i, step := range steps {
+		fmt.Fprintf(&b, "  %d. %s\n", i+1, step)
+	}
+	b.WriteString("\n")
+
+	preview := m.quickPreview
+	switch {
+	case preview.checking:
+		b.WriteString("Checking the project and task still exist…\n\nn = cancel")
+	case preview.failure != "":
+		b.WriteString(errorStyle.Render("✗ "+preview.failure) + "\n\nn = cancel")
+	case preview.unverified != "":
+		b.WriteString("Couldn't check the project and task: " + preview.unverified + "\n\ny = run anyway, n = cancel")
+	default:
+		b.WriteString("y = run, n = cancel")
+	}
+	return b.String()
+}
+
+// Help lines for the configured quick actions, in key order
+func (m Model) quickActionsHelp() string {
+	if len(m.config.QuickActions) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(m.config.QuickActions))
+	for key := range m.config.QuickActions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("\nQUICK ACTIONS\n")
+	for _, key := range keys {
+		action := m.config.QuickActions[key]
+		fmt.Fprintf(&b, "  %-10s Start %s", key, action.Alias)
+		if action.Notes != "" {
+			fmt.Fprintf(&b, " — %s", action.Notes)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
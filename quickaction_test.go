@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestQuickActionKeysWhileTyping(t *testing.T) {
+	keys, err := newKeyMap(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := Model{
+		keys:  keys,
+		state: "enter_details",
+		config: Configuration{QuickActions: map[string]QuickAction{
+			"f2": {Alias: "standup"},
+			"z":  {Alias: "standup"},
+		}},
+	}
+
+	if !m.globalWhileTyping("f2") {
+		t.Error("f2 quick action blocked while typing notes")
+	}
+	if m.globalWhileTyping("z") {
+		t.Error("a quick action on a printable key fired while typing notes")
+	}
+
+	m.state = "select_project"
+	if m.globalWhileTyping("f2") {
+		t.Error("quick action fired while filtering a list")
+	}
+}
+
+func TestStartQuickActionAlreadyRunning(t *testing.T) {
+	m := Model{
+		selectedProject: Project{ID: 11},
+		selectedTask:    Task{ID: 22},
+		activeTimer:     &Timer{ID: 1, ProjectID: 11, TaskID: 22, Notes: "Standup"},
+	}
+	m.ticketInput.SetValue("Standup")
+
+	model, cmd := m.startQuickAction()
+	if got := model.(Model); cmd != nil || got.success != "That timer is already running" {
+		t.Errorf("got success %q and a command %v", got.success, cmd != nil)
+	}
+}
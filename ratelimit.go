@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Harvest allows 100 requests per 15 seconds for most endpoints
+const (
+	rateLimitRequests = 100
+	rateLimitWindow   = 15 * time.Second
+)
+
+// Latency samples kept per endpoint, and endpoints shown in the panel
+const (
+	latencySamples       = 20
+	shownLatencyEndpoint = 3
+)
+
+// requestStats counts API requests in the current rate-limit window and
+// keeps recent latencies per endpoint. It is updated from resty hooks on the
+// request goroutines and read by the view.
+type requestStats struct {
+	mu          sync.Mutex
+	windowStart time.Time
+	inWindow    int
+	total       int
+	throttled   int
+	latencies   map[string][]time.Duration // endpoint -> newest samples
+}
+
+// Count a request, starting a new window once the current one has passed
+func (s *requestStats) record(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.windowStart) >= rateLimitWindow {
+		s.windowStart = now
+		s.inWindow = 0
+	}
+	s.inWindow++
+	s.total++
+}
+
+// Count a response rejected by the rate limit
+func (s *requestStats) recordThrottled() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.throttled++
+}
+
+// Keep a response time, dropping the oldest sample once the window is full
+func (s *requestStats) recordLatency(endpoint string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.latencies == nil {
+		s.latencies = make(map[string][]time.Duration)
+	}
+	samples := append(s.latencies[endpoint], d)
+	if len(samples) > latencySamples {
+		samples = samples[len(samples)-latencySamples:]
+	}
+	s.latencies[endpoint] = samples
+}
+
+// Endpoint of a request as "GET /time_entries/:id", without the API
+// version. Record IDs are folded so an endpoint's requests share one series.
+func endpointName(method, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 1 && apiVersionPattern.MatchString(segments[0]) {
+		segments = segments[1:]
+	}
+	for i, segment := range segments {
+		if _, err := strconv.Atoi(segment); err == nil {
+			segments[i] = ":id"
+		}
+	}
+	return method + " /" + strings.Join(segments, "/")
+}
+
+// Hook the counters into a resty client
+func (s *requestStats) attach(client *resty.Client) {
+	client.OnBeforeRequest(func(*resty.Client, *resty.Request) error {
+		s.record(time.Now())
+		return nil
+	})
+	client.OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			s.recordThrottled()
+		}
+		if raw := resp.Request.RawRequest; raw != nil {
+			s.recordLatency(endpointName(raw.Method, raw.URL.Path), resp.Time())
+		}
+		return nil
+	})
+}
+
+// Set by the --diagnostics flag. Kept out of the configuration so saving it
+// doesn't make the flag permanent.
+var diagnosticsFlag bool
+
+// Whether the diagnostics panel is on, from the config file or the flag
+func (c Configuration) diagnostics() bool {
+	return c.Diagnostics || diagnosticsFlag
+}
+
+// One-line summary of the request budget for the diagnostics panel
+func (s *requestStats) summary(now time.Time) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	used, reset := s.inWindow, rateLimitWindow-now.Sub(s.windowStart)
+	if reset <= 0 {
+		used, reset = 0, 0
+	}
+
+	return fmt.Sprintf("API %d/%d this window · %d left · resets in %ds · %d total · %d throttled",
+		used, rateLimitRequests, max(rateLimitRequests-used, 0), int(reset.Seconds()), s.total, s.throttled)
+}
+
+// Latency of each endpoint over its recent samples as
+// "GET /time_entries  min 120ms · avg 180ms · max 400ms (12)", slowest
+// average first. A limit above zero keeps only the slowest endpoints.
+func (s *requestStats) latencyLines(limit int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	type latency struct {
+		endpoint      string
+		min, avg, max time.Duration
+		count         int
+	}
+	var all []latency
+	for endpoint, samples := range s.latencies {
+		l := latency{endpoint: endpoint, min: samples[0], count: len(samples)}
+		var sum time.Duration
+		for _, d := range samples {
+			l.min, l.max = min(l.min, d), max(l.max, d)
+			sum += d
+		}
+		l.avg = sum / time.Duration(len(samples))
+		all = append(all, l)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].avg != all[j].avg {
+			return all[i].avg > all[j].avg
+		}
+		return all[i].endpoint < all[j].endpoint
+	})
+	if limit > 0 && len(all) > limit {
+		all = all[:limit]
+	}
+
+	lines := make([]string, len(all))
+	for i, l := range all {
+		lines[i] = fmt.Sprintf("%s  min %s · avg %s · max %s (%d)", l.endpoint,
+			l.min.Round(time.Millisecond), l.avg.Round(time.Millisecond), l.max.Round(time.Millisecond), l.count)
+	}
+	return lines
+}
+
+// Diagnostics panel, shown only when diagnostics are turned on: the request
+// budget, then the slowest endpoints
+func (m Model) diagnosticsView() string {
+	if !m.config.diagnostics() {
+		return ""
+	}
+
+	// Keep the panel's height fixed so the lists below don't jump as
+	// endpoints are first called
+	latency := m.harvestClient.stats.latencyLines(shownLatencyEndpoint)
+	if len(latency) == 0 {
+		latency = []string{"No API responses yet"}
+	}
+	lines := make([]string, 1+shownLatencyEndpoint)
+	lines[0] = m.harvestClient.stats.summary(time.Now())
+	copy(lines[1:], latency)
+	return infoStyle.Render(strings.Join(lines, "\n")) + "\n\n"
+}
+
+// Lines the diagnostics panel takes, including the blank line after it
+func (m Model) diagnosticsHeight() int {
+	if !m.config.diagnostics() {
+		return 0
+	}
+	return 2 + shownLatencyEndpoint
+}
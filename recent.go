@@ -0,0 +1,98 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Projects kept in the recently used list
+const maxRecentProjects = 10
+
+// recentUse is a project timers were started on, with the last task used
+type recentUse struct {
+	Project Project `json:"project"`
+	Task    Task    `json:"task"`
+}
+
+// Load the recently used projects, newest first, starting empty when the
+// file is missing or corrupt
+func loadRecent() []recentUse {
+	var recent []recentUse
+	if !recentFile.load(&recent) {
+		return nil
+	}
+	return recent
+}
+
+// Move a project to the front of the list, remembering its task
+func addRecent(recent []recentUse, project Project, task Task) []recentUse {
+	updated := []recentUse{{Project: project, Task: task}}
+	for _, r := range recent {
+		if r.Project.ID != project.ID && len(updated) < maxRecentProjects {
+			updated = append(updated, r)
+		}
+	}
+	return updated
+}
+
+// Most recently used project other than the current one, which is the
+// running timer's project, or else the most recently used
+func (m Model) swapTarget() (recentUse, bool) {
+	if len(m.recent) == 0 {
+		return recentUse{}, false
+	}
+
+	current := m.recent[0].Project.ID
+	if m.activeTimer != nil && m.activeTimer.ProjectID != 0 {
+		current = m.activeTimer.ProjectID
+	}
+
+	for _, r := range m.recent {
+		// Never "swap" to the project the timer is already running on
+		if r.Project.ID != current && (m.activeTimer == nil || r.Project.ID != m.activeTimer.ProjectID) {
+			return r, true
+		}
+	}
+	return recentUse{}, false
+}
+
+// Jump to the other recently used project, going straight to its notes when
+// its last task is still available
+func (m Model) swapProject() (tea.Model, tea.Cmd) {
+	m.error = ""
+	m.success = ""
+
+	target, ok := m.swapTarget()
+	if !ok {
+		m.success = "Start timers on two different projects to swap between them"
+		return m, nil
+	}
+
+	m.selectedProject = target.Project
+	m.swapTask = target.Task
+	m.resizeLists()
+	m.state = "loading_tasks"
+	return m, fetchTasks(m.harvestClient, target.Project.ID)
+}
+
+// Pick the task remembered by a swap once the project's tasks are loaded.
+// Returns false when it isn't among them, leaving the task list up.
+func (m *Model) selectSwapTask() bool {
+	task := m.swapTask
+	m.swapTask = Task{}
+	if task.ID == 0 {
+		return false
+	}
+
+	for i, t := range m.tasks {
+		if t.ID == task.ID {
+			m.taskList.Select(i)
+			m.selectedTask = t
+			m.state = "enter_details"
+			m.ticketInput.Focus()
+			m.historyCursor = 0
+			m.restoreDraft()
+			return true
+		}
+	}
+	return false
+}
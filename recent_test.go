@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func TestSwapTarget(t *testing.T) {
+	acme := recentUse{Project: Project{ID: 1, Name: "Acme"}, Task: Task{ID: 10}}
+	globex := recentUse{Project: Project{ID: 2, Name: "Globex"}, Task: Task{ID: 20}}
+	initech := recentUse{Project: Project{ID: 3, Name: "Initech"}, Task: Task{ID: 30}}
+
+	tests := []struct {
+		name    string
+		recent  []recentUse
+		running *Timer
+		want    int // project ID, zero for no target
+	}{
+		{"no recent projects", nil, nil, 0},
+		{"only one project", []recentUse{acme}, nil, 0},
+		{"no timer swaps to the second most recent", []recentUse{acme, globex, initech}, nil, 2},
+		{"running on the most recent", []recentUse{acme, globex}, &Timer{ProjectID: 1}, 2},
+		{"running on the second most recent swaps back", []recentUse{acme, globex}, &Timer{ProjectID: 2}, 1},
+		{"running elsewhere swaps to the most recent", []recentUse{acme, globex}, &Timer{ProjectID: 3}, 1},
+		{"only the running project", []recentUse{acme}, &Timer{ProjectID: 1}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{recent: tt.recent, activeTimer: tt.running}
+			target, ok := m.swapTarget()
+			if got := target.Project.ID; got != tt.want || ok != (tt.want != 0) {
+				t.Errorf("got project %d (%v), want %d", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddRecentMovesProjectToFront(t *testing.T) {
+	var recent []recentUse
+	recent = addRecent(recent, Project{ID: 1}, Task{ID: 10})
+	recent = addRecent(recent, Project{ID: 2}, Task{ID: 20})
+	recent = addRecent(recent, Project{ID: 1}, Task{ID: 11})
+
+	if len(recent) != 2 || recent[0].Project.ID != 1 || recent[0].Task.ID != 11 || recent[1].Project.ID != 2 {
+		t.Errorf("got %+v", recent)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// How long the "what changed" notice stays up after a background refresh
+const noticeDuration = 5 * time.Second
+
+type (
+	refreshMsg   struct{}
+	refreshedMsg struct {
+		projects  []Project
+		skipped   int
+		projectID int // project the tasks belong to, zero when not refetched
+		tasks     []Task
+	}
+	clearNoticeMsg struct{ id int }
+)
+
+// Schedule the next background refresh of the project and task lists
+func scheduleRefresh(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return refreshMsg{}
+	})
+}
+
+// Refetch the projects, and the tasks of the selected project when they are
+// on screen, without touching the current state
+func (m Model) backgroundRefresh() tea.Cmd {
+	// The initial fetch is still running or has failed, nothing to compare to
+	if m.projects == nil || m.state == "loading_projects" || m.state == "loading_tasks" {
+		return nil
+	}
+
+	client := m.harvestClient
+	projectID := 0
+	if m.tasks != nil && m.selectedProject.ID != 0 {
+		projectID = m.selectedProject.ID
+	}
+
+	return func() tea.Msg {
+		projects, skipped, err := client.GetProjects()
+		if err != nil {
+			return errorMsg{error: err.Error()}
+		}
+
+		msg := refreshedMsg{projects: projects, skipped: skipped}
+		if projectID != 0 {
+			if msg.tasks, err = client.GetTasks(projectID); err != nil {
+				return errorMsg{error: err.Error()}
+			}
+			msg.projectID = projectID
+		}
+		return msg
+	}
+}
+
+// Replace the lists with the refreshed data and briefly show what changed
+func (m *Model) applyRefresh(msg refreshedMsg) tea.Cmd {
+	var changes []string
+
+	added, removed := diffIDs(projectIDs(m.projects), projectIDs(msg.projects))
+	if added > 0 {
+		changes = append(changes, fmt.Sprintf("+%d new %s", added, plural(added, "project")))
+	}
+	if removed > 0 {
+		changes = append(changes, fmt.Sprintf("%d archived", removed))
+	}
+	m.projects = msg.projects
+	m.projectsSkipped = msg.skipped
+	m.setProjectItems()
+
+	// Drop tasks for a project that was left while the refresh was running
+	if msg.projectID != 0 && msg.projectID == m.selectedProject.ID && m.tasks != nil {
+		added, removed := diffIDs(taskIDs(m.tasks), taskIDs(msg.tasks))
+		if added > 0 {
+			changes = append(changes, fmt.Sprintf("+%d new %s", added, plural(added, "task")))
+		}
+		if removed > 0 {
+			changes = append(changes, fmt.Sprintf("%d %s removed", removed, plural(removed, "task")))
+		}
+		m.tasks = msg.tasks
+		m.setTaskItems()
+	}
+
+	cmd := m.fetchBudgets()
+	if len(changes) == 0 {
+		return cmd
+	}
+
+	m.noticeID++
+	m.notice = strings.Join(changes, ", ")
+	id := m.noticeID
+	return tea.Batch(cmd, tea.Tick(noticeDuration, func(time.Time) tea.Msg {
+		return clearNoticeMsg{id: id}
+	}))
+}
+
+func projectIDs(projects []Project) map[int]bool {
+	ids := make(map[int]bool, len(projects))
+	for _, project := range projects {
+		ids[project.ID] = true
+	}
+	return ids
+}
+
+func taskIDs(tasks []Task) map[int]bool {
+	ids := make(map[int]bool, len(tasks))
+	for _, task := range tasks {
+		ids[task.ID] = true
+	}
+	return ids
+}
+
+// Count the IDs only in after (added) and only in before (removed)
+func diffIDs(before, after map[int]bool) (added, removed int) {
+	for id := range after {
+		if !before[id] {
+			added++
+		}
+	}
+	for id := range before {
+		if !after[id] {
+			removed++
+		}
+	}
+	return added, removed
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
+
+// Dim one-line notice under the title while active
+func (m Model) noticeView() string {
+	if m.notice == "" {
+		return ""
+	}
+	return infoStyle.Render("↻ Lists updated: "+m.notice) + "\n\n"
+}
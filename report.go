@@ -0,0 +1,377 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Report is a period of time entries ready to be written by a Formatter
+type Report struct {
+	User    string      `json:"user,omitempty"`
+	Period  string      `json:"period"`
+	From    string      `json:"from"`
+	To      string      `json:"to"`
+	Entries []ReportRow `json:"entries"`
+	Total   float64     `json:"total_hours"`
+
+	// Billable amount, nil when no entry has a rate
+	Earnings *Earnings `json:"billable_amount,omitempty"`
+	Currency string    `json:"currency,omitempty"`
+
+	// Leave hours out of formats meant for people, e.g. client emails
+	HideHours bool `json:"-"`
+
+	// First day of the week for weekly totals
+	WeekStart time.Weekday `json:"-"`
+}
+
+// ReportRow is one time entry in a report
+type ReportRow struct {
+	Date     string  `json:"date"`
+	Project  string  `json:"project"`
+	Task     string  `json:"task"`
+	Hours    float64 `json:"hours"`
+	Billable bool    `json:"billable"`
+	Notes    string  `json:"notes"`
+}
+
+// Formatter writes a report in one output format. Adding a format only
+// takes an implementation and an entry in reportFormatters.
+type Formatter interface {
+	Format(w io.Writer, report Report) error
+}
+
+// Report formats by --format name
+var reportFormatters = map[string]Formatter{
+	"csv":       csvFormatter{comma: ','},
+	"tsv":       csvFormatter{comma: '\t'},
+	"json":      jsonFormatter{},
+	"markdown":  markdownFormatter{},
+	"timesheet": timesheetFormatter{width: 72},
+	"email":     emailFormatter{},
+}
+
+// Names of the available report formats, sorted for help output
+func reportFormatNames() []string {
+	names := make([]string, 0, len(reportFormatters))
+	for name := range reportFormatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Build a report from time entries, oldest first
+func newReport(period, from, to string, entries []TimeEntry) Report {
+	report := Report{Period: period, From: from, To: to, Entries: []ReportRow{}}
+	for _, entry := range entries {
+		report.Entries = append(report.Entries, ReportRow{
+			Date:     entry.SpentDate,
+			Project:  entry.Project.Name,
+			Task:     entry.Task.Name,
+			Hours:    entry.Hours,
+			Billable: entry.Billable,
+			Notes:    entry.Notes,
+		})
+		report.Total += entry.Hours
+	}
+
+	if earnings, rated := sumEarnings(entries, func(e TimeEntry) float64 { return e.Hours }); rated {
+		report.Earnings = &earnings
+	}
+
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		return report.Entries[i].Date < report.Entries[j].Date
+	})
+	return report
+}
+
+// Date range of the day, week or month containing a date
+func reportPeriod(period string, date time.Time, first time.Weekday) (from, to time.Time, err error) {
+	switch period {
+	case "day":
+		return date, date, nil
+	case "week":
+		from = weekStart(date, first)
+		return from, from.AddDate(0, 0, 6), nil
+	case "month":
+		from = time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+		return from, from.AddDate(0, 1, -1), nil
+	}
+	return from, to, fmt.Errorf("--period must be day, week or month")
+}
+
+// csvFormatter writes one row per entry with a header, comma or tab separated
+type csvFormatter struct{ comma rune }
+
+func (f csvFormatter) Format(w io.Writer, report Report) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = f.comma
+	cw.Write([]string{"date", "project", "task", "hours", "billable", "notes"})
+	for _, row := range report.Entries {
+		cw.Write([]string{
+			row.Date, row.Project, row.Task,
+			strconv.FormatFloat(row.Hours, 'f', 2, 64),
+			strconv.FormatBool(row.Billable), row.Notes,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// jsonFormatter writes the whole report as an indented JSON object
+type jsonFormatter struct{}
+
+func (jsonFormatter) Format(w io.Writer, report Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// markdownFormatter writes a table followed by the total and billable amount
+type markdownFormatter struct{}
+
+func (markdownFormatter) Format(w io.Writer, report Report) error {
+	// Pipes and line breaks would end the table cell
+	cell := strings.NewReplacer("|", `\|`, "\n", " ").Replace
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Time report %s to %s\n\n", report.From, report.To)
+	b.WriteString("| Date | Project | Task | Hours | Billable | Notes |\n")
+	b.WriteString("| --- | --- | --- | ---: | --- | --- |\n")
+	for _, row := range report.Entries {
+		billable := "no"
+		if row.Billable {
+			billable = "yes"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %.2f | %s | %s |\n",
+			row.Date, cell(row.Project), cell(row.Task), row.Hours, billable, cell(row.Notes))
+	}
+	fmt.Fprintf(&b, "\n**Total: %.2fh**\n", report.Total)
+	if report.Earnings != nil {
+		fmt.Fprintf(&b, "\n**Billable amount:** %s\n", report.Earnings.label(report.Currency))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// timesheetFormatter writes a Markdown document grouped by day with daily
+// and weekly totals, meant to be read or converted to PDF rather than parsed
+type timesheetFormatter struct{ width int }
+
+func (f timesheetFormatter) Format(w io.Writer, report Report) error {
+	var b strings.Builder
+	b.WriteString("# Timesheet\n\n")
+	if report.User != "" {
+		fmt.Fprintf(&b, "**Name:** %s  \n", report.User)
+	}
+	fmt.Fprintf(&b, "**Period:** %s to %s\n", report.From, report.To)
+
+	if len(report.Entries) == 0 {
+		b.WriteString("\nNo time tracked in this period.\n")
+	}
+
+	var dayTotal, weekTotal float64
+	for i, row := range report.Entries {
+		date, _ := time.Parse("2006-01-02", row.Date)
+		if i == 0 || report.Entries[i-1].Date != row.Date {
+			fmt.Fprintf(&b, "\n## %s\n\n", date.Format("Monday, 2 January 2006"))
+		}
+
+		fmt.Fprintf(&b, "- **%s / %s** — %.2fh\n", row.Project, row.Task, row.Hours)
+		if row.Notes != "" {
+			// Indented continuation lines stay part of the list item
+			notes := ansi.Wordwrap(strings.ReplaceAll(row.Notes, "\n", " "), f.width-2, "")
+			b.WriteString("  " + strings.ReplaceAll(notes, "\n", "\n  ") + "\n")
+		}
+		dayTotal += row.Hours
+		weekTotal += row.Hours
+
+		last := i == len(report.Entries)-1
+		if last || report.Entries[i+1].Date != row.Date {
+			fmt.Fprintf(&b, "\n*Day total: %.2fh*\n", dayTotal)
+			dayTotal = 0
+		}
+
+		if report.Period != "day" {
+			start := weekStart(date, report.WeekStart)
+			next := start
+			if !last {
+				next, _ = time.Parse("2006-01-02", report.Entries[i+1].Date)
+			}
+			if last || !weekStart(next, report.WeekStart).Equal(start) {
+				fmt.Fprintf(&b, "\n**Week of %s: %.2fh**\n", start.Format("2 January"), weekTotal)
+				weekTotal = 0
+			}
+		}
+	}
+
+	fmt.Fprintf(&b, "\n---\n\n**Total: %.2fh**\n", report.Total)
+	if report.Earnings != nil {
+		fmt.Fprintf(&b, "\n**Billable amount:** %s\n", report.Earnings.label(report.Currency))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// emailFormatter writes a short plain-text update grouped by project, for
+// pasting into an email
+type emailFormatter struct{}
+
+func (emailFormatter) Format(w io.Writer, report Report) error {
+	var b strings.Builder
+	if report.From == report.To {
+		fmt.Fprintf(&b, "Work summary for %s\n\n", report.From)
+	} else {
+		fmt.Fprintf(&b, "Work summary for %s to %s\n\n", report.From, report.To)
+	}
+
+	if len(report.Entries) == 0 {
+		b.WriteString("No time tracked in this period.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	// Projects in the order they were first worked on
+	var projects []string
+	byProject := make(map[string][]ReportRow)
+	for _, row := range report.Entries {
+		if _, ok := byProject[row.Project]; !ok {
+			projects = append(projects, row.Project)
+		}
+		byProject[row.Project] = append(byProject[row.Project], row)
+	}
+
+	for _, project := range projects {
+		rows := byProject[project]
+		if report.HideHours {
+			fmt.Fprintf(&b, "%s\n", project)
+		} else {
+			var hours float64
+			for _, row := range rows {
+				hours += row.Hours
+			}
+			fmt.Fprintf(&b, "%s (%.2fh)\n", project, hours)
+		}
+
+		// One bullet per distinct piece of work, merging repeated notes
+		seen := make(map[string]bool)
+		for _, row := range rows {
+			item := row.Notes
+			if item == "" {
+				item = row.Task
+			}
+			if seen[item] {
+				continue
+			}
+			seen[item] = true
+			fmt.Fprintf(&b, "  - %s\n", strings.ReplaceAll(item, "\n", " "))
+		}
+		b.WriteString("\n")
+	}
+
+	if !report.HideHours {
+		fmt.Fprintf(&b, "Total: %.2fh\n", report.Total)
+	}
+
+	_, err := io.WriteString(w, strings.TrimRight(b.String(), "\n")+"\n")
+	return err
+}
+
+// Print the time entries of a day, week or month
+func runReport(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	period := fs.String("period", "day", "day, week or month")
+	date := fs.String("date", time.Now().Format("2006-01-02"), "any date in the period (YYYY-MM-DD)")
+	format := fs.String("format", "csv", "output format: "+strings.Join(reportFormatNames(), ", "))
+	out := fs.String("out", "", "write to this file instead of stdout")
+	toClipboard := fs.Bool("to-clipboard", false, "copy the report to the clipboard instead of printing it")
+	noHours := fs.Bool("no-hours", false, "leave hours out of the email format")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	formatter, ok := reportFormatters[*format]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown --format %q, expected one of: %s\n", *format, strings.Join(reportFormatNames(), ", "))
+		return 2
+	}
+
+	day, err := time.Parse("2006-01-02", *date)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --date %q, expected YYYY-MM-DD\n", *date)
+		return 2
+	}
+	config, err := commandConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	client := NewHarvestClient(config)
+
+	from, to, err := reportPeriod(*period, day, config.firstWeekday())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	client.userID = user.ID
+
+	fromDate, toDate := from.Format("2006-01-02"), to.Format("2006-01-02")
+	entries, err := client.GetTimeEntries(fromDate, toDate, time.Time{})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	report := newReport(*period, fromDate, toDate, entries)
+	report.HideHours = *noHours
+	if report.Earnings != nil {
+		report.Currency = config.Currency
+	}
+	report.WeekStart = config.firstWeekday()
+	report.User = user.Name()
+
+	var b strings.Builder
+	if err := formatter.Format(&b, report); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	// --out and --to-clipboard both get the report; stdout only without either
+	if *out != "" {
+		if err := os.WriteFile(*out, []byte(b.String()), 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+	if *toClipboard {
+		if err := clipboard.WriteAll(b.String()); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to copy to clipboard: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "Copied the %s report to the clipboard\n", *format)
+	}
+	if *out == "" && !*toClipboard {
+		fmt.Print(b.String())
+	}
+	return 0
+}
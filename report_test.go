@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// Two weeks of entries covering multi-entry days, repeated notes, an empty
+// note, notes with Markdown and CSV special characters, and a long note
+func goldenReport() Report {
+	return Report{
+		User:   "Ada Lovelace",
+		Period: "month",
+		From:   "2025-03-01",
+		To:     "2025-03-31",
+		Entries: []ReportRow{
+			{Date: "2025-03-07", Project: "Website", Task: "Design", Hours: 2.5, Billable: true, Notes: "Homepage mockups"},
+			{Date: "2025-03-07", Project: "Internal", Task: "Meetings", Hours: 0.75, Notes: ""},
+			{Date: "2025-03-10", Project: "Website", Task: "Development", Hours: 4, Billable: true, Notes: "Nav | footer, \"sticky\" header"},
+			{Date: "2025-03-10", Project: "Website", Task: "Design", Hours: 1.25, Billable: true, Notes: "Homepage mockups"},
+			{Date: "2025-03-11", Project: "Internal", Task: "Admin", Hours: 0.5, Notes: "Expenses\nand timesheets"},
+			{Date: "2025-03-11", Project: "Mobile App", Task: "Development", Hours: 3, Billable: true,
+				Notes: "Offline sync for the settings screen, including conflict handling when the same field changed on two devices"},
+		},
+		Total:     12,
+		Earnings:  &Earnings{Amount: 1290, Unrated: 1},
+		Currency:  "€",
+		WeekStart: time.Monday,
+	}
+}
+
+func TestReportFormatters(t *testing.T) {
+	extensions := map[string]string{
+		"csv": "csv", "tsv": "tsv", "json": "json",
+		"markdown": "md", "timesheet": "md", "email": "txt",
+	}
+
+	for _, name := range reportFormatNames() {
+		t.Run(name, func(t *testing.T) {
+			ext, ok := extensions[name]
+			if !ok {
+				t.Fatalf("no golden file for format %q", name)
+			}
+
+			var buf bytes.Buffer
+			if err := reportFormatters[name].Format(&buf, goldenReport()); err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", "report-"+name+"."+ext)
+			if *update {
+				if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("output differs from %s:\n%s", golden, buf.String())
+			}
+		})
+	}
+}
+
+func TestEmailFormatterHideHours(t *testing.T) {
+	report := goldenReport()
+	report.HideHours = true
+
+	var buf bytes.Buffer
+	if err := (emailFormatter{}).Format(&buf, report); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("h)")) || bytes.Contains(buf.Bytes(), []byte("Total")) {
+		t.Errorf("hours shown with HideHours set:\n%s", buf.String())
+	}
+}
+
+func TestReportFormattersEmpty(t *testing.T) {
+	report := Report{Period: "day", From: "2025-03-07", To: "2025-03-07", Entries: []ReportRow{}}
+	for _, name := range reportFormatNames() {
+		var buf bytes.Buffer
+		if err := reportFormatters[name].Format(&buf, report); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if buf.Len() == 0 {
+			t.Errorf("%s: no output for an empty report", name)
+		}
+	}
+}
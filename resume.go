@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Time entry details for the running timer, used to name its project/task
+type timerEntryMsg struct{ entry TimeEntry }
+
+// Ask what to do with a timer left running by the last session. Returns to
+// the state the app was in once answered.
+func (m *Model) offerResume() {
+	m.resumeReturn = m.state
+	m.state = "resume_timer"
+}
+
+// Handle the resume prompt shown at startup
+func (m Model) updateResumePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.activeTimer == nil {
+		// Stopped elsewhere while the prompt was open
+		m.state = m.resumeReturn
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "c", "enter":
+		// Pick up the running timer as if it had been started here
+		m.selectedProject = m.timerProject(m.activeTimer)
+		m.selectedTask = m.timerTask(m.activeTimer)
+		m.ticketInput.SetValue(m.activeTimer.Notes)
+		m.ticketInput.Blur()
+		m.state = "enter_details"
+	case "s":
+		m.state = m.resumeReturn
+		return m.stopAboveMinimum()
+	case "i", "esc":
+		m.state = m.resumeReturn
+	}
+	return m, nil
+}
+
+// Fill in the project and task names of a timer from the fetched lists and
+// today's entries. Returns false when either is still unknown.
+func (m Model) resolveTimerNames(timer *Timer) bool {
+	for _, project := range m.projects {
+		if project.ID == timer.ProjectID {
+			timer.ProjectName = project.Name
+		}
+	}
+	if m.selectedProject.ID == timer.ProjectID {
+		for _, task := range m.tasks {
+			if task.ID == timer.TaskID {
+				timer.TaskName = task.Name
+			}
+		}
+	}
+	for _, entry := range m.todayEntries {
+		if entry.ID == timer.ID {
+			timer.ProjectName, timer.TaskName = entry.Project.Name, entry.Task.Name
+		}
+	}
+	return timer.ProjectName != "" && timer.TaskName != ""
+}
+
+// Command to fetch the running timer's entry when its project or task
+// isn't in the lists, e.g. because the project was archived
+func fetchTimerEntry(client *HarvestClient, id int) tea.Cmd {
+	return func() tea.Msg {
+		entry, err := client.GetTimeEntry(id)
+		if err != nil {
+			return errorMsg{error: err.Error()}
+		}
+		return timerEntryMsg{entry: *entry}
+	}
+}
+
+// Name the running timer's project and task from its entry details
+func (m *Model) applyTimerEntry(entry TimeEntry) {
+	if m.activeTimer == nil || m.activeTimer.ID != entry.ID {
+		return
+	}
+
+	m.activeTimer.ProjectID, m.activeTimer.ProjectName = entry.Project.ID, entry.Project.Name
+	m.activeTimer.TaskID, m.activeTimer.TaskName = entry.Task.ID, entry.Task.Name
+
+	// Continued before the details arrived
+	if m.state == "enter_details" && m.selectedProject.Name == "" {
+		m.selectedProject = m.timerProject(m.activeTimer)
+		m.selectedTask = m.timerTask(m.activeTimer)
+	}
+}
+
+// The project a timer belongs to, by name once resolved
+func (m Model) timerProject(timer *Timer) Project {
+	for _, project := range m.projects {
+		if project.ID == timer.ProjectID {
+			return project
+		}
+	}
+	return Project{ID: timer.ProjectID, Name: timer.ProjectName}
+}
+
+// The task a timer belongs to, by name once resolved
+func (m Model) timerTask(timer *Timer) Task {
+	return Task{ID: timer.TaskID, Name: timer.TaskName}
+}
+
+// Project and task of a timer for display, falling back to the IDs while
+// the names are being fetched
+func timerLabel(timer *Timer) string {
+	project, task := timer.ProjectName, timer.TaskName
+	if project == "" {
+		project = "#" + strconv.Itoa(timer.ProjectID)
+	}
+	if task == "" {
+		task = "#" + strconv.Itoa(timer.TaskID)
+	}
+	return project + " / " + task
+}
+
+// Prompt shown when a timer is already running at startup
+func (m Model) resumePrompt() string {
+	return fmt.Sprintf("You have a timer running: %s (%s)\n%s\n\n"+
+		"c = continue, s = stop, i = ignore",
+		timerLabel(m.activeTimer),
+		formatElapsed(m.elapsed(), false),
+		infoStyle.Render(m.activeTimer.Notes))
+}
@@ -0,0 +1,24 @@
+package main
+
+// Shown when a destructive action is attempted in safe mode
+const safeModeMessage = "Disabled in safe mode."
+
+// Set by the --safe-mode flag, which also applies to subcommands. Kept out
+// of the configuration so saving it doesn't make the flag permanent.
+var safeModeFlag bool
+
+// Whether safe mode is on, from the config file or the flag
+func (c Configuration) safeMode() bool {
+	return c.SafeMode || safeModeFlag
+}
+
+// Refuse a destructive action in safe mode, saying so. Returns true when
+// the action must not run.
+func (m *Model) refuseInSafeMode() bool {
+	if !m.config.safeMode() {
+		return false
+	}
+	m.success = ""
+	m.error = safeModeMessage
+	return true
+}
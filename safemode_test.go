@@ -0,0 +1,125 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Turn on safe mode through the flag for the rest of the test
+func setSafeModeFlag(t *testing.T) {
+	t.Helper()
+	safeModeFlag = true
+	t.Cleanup(func() { safeModeFlag = false })
+}
+
+func TestSafeModeFlagNotInConfig(t *testing.T) {
+	setSafeModeFlag(t)
+
+	var config Configuration
+	if !config.safeMode() {
+		t.Error("safe mode off with the flag set")
+	}
+	if config.SafeMode {
+		t.Error("flag copied into the configuration, where saving it would persist it")
+	}
+}
+
+func TestSafeModeRefusesDelete(t *testing.T) {
+	m := Model{
+		config:         Configuration{SafeMode: true},
+		state:          "daily_summary",
+		summaryEntries: []TimeEntry{{ID: 7, Hours: 1}},
+	}
+
+	model, cmd := m.deleteSelectedEntry()
+	got := model.(Model)
+	if cmd != nil || got.state != "daily_summary" {
+		t.Errorf("delete went ahead: state %q, cmd %v", got.state, cmd != nil)
+	}
+	if got.error != safeModeMessage {
+		t.Errorf("error = %q, want %q", got.error, safeModeMessage)
+	}
+}
+
+func TestSafeModeRefusesBulkEdit(t *testing.T) {
+	setSafeModeFlag(t)
+	m := Model{
+		state:          "daily_summary",
+		summaryEntries: []TimeEntry{{ID: 7, Hours: 1}},
+	}
+
+	model, cmd := m.openBulkEdit()
+	got := model.(Model)
+	if cmd != nil || got.state != "daily_summary" {
+		t.Errorf("bulk edit opened: state %q", got.state)
+	}
+	if got.error != safeModeMessage {
+		t.Errorf("error = %q, want %q", got.error, safeModeMessage)
+	}
+}
+
+func TestSafeModeKeepsSwitchedTimer(t *testing.T) {
+	m := Model{
+		config:         Configuration{SafeMode: true},
+		activeTimer:    &Timer{ID: 1},
+		timerStartedAt: time.Now(),
+	}
+
+	if cmd := m.discardSwitched(&Timer{ID: 2}); cmd != nil {
+		t.Error("timer within the switch grace period discarded in safe mode")
+	}
+	if m.success != "" {
+		t.Errorf("success = %q, want no discard note", m.success)
+	}
+}
+
+func TestSafeModeStopsWithoutDiscardPrompt(t *testing.T) {
+	m := Model{
+		config:         Configuration{SafeMode: true, MinimumMinutes: 5},
+		state:          "select_project",
+		activeTimer:    &Timer{ID: 1},
+		timerStartedAt: time.Now(),
+	}
+
+	model, cmd := m.stopAboveMinimum()
+	if got := model.(Model); got.state == "confirm_discard" {
+		t.Error("offered to discard a short timer in safe mode")
+	}
+	if cmd == nil {
+		t.Error("timer not stopped")
+	}
+}
+
+func TestSafeModeIdleStopKeepsHours(t *testing.T) {
+	setSafeModeFlag(t)
+
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	m := Model{
+		harvestClient:  NewHarvestClient(Configuration{BaseURL: server.URL + "/v2"}),
+		activeTimer:    &Timer{ID: 1},
+		timerStartedAt: time.Now().Add(-time.Hour),
+		idleSince:      time.Now().Add(-30 * time.Minute),
+	}
+	m.stopIdle()()
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"PATCH /v2/time_entries/1/stop"}
+	if !slices.Equal(requests, want) {
+		t.Errorf("requests = %q, want %q (no hours update)", requests, want)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// searchTask is one project/task pair in the global task search
+type searchTask struct {
+	project Project
+	task    Task
+}
+
+type searchIndexMsg struct{ tasks []searchTask }
+
+// Command to collect the tasks of all assigned projects, one entry per
+// project/task pair. Project assignments are used because the plain
+// /task_assignments endpoint needs admin rights.
+func fetchSearchIndex(client *HarvestClient) tea.Cmd {
+	return func() tea.Msg {
+		assignments, err := client.GetProjectAssignments()
+		if err != nil {
+			return errorMsg{error: err.Error()}
+		}
+
+		seen := make(map[[2]int]bool)
+		tasks := []searchTask{}
+		for _, a := range assignments {
+			a.Project.Client = a.Client.Name
+			for _, ta := range a.TaskAssignments {
+				key := [2]int{a.Project.ID, ta.Task.ID}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				task := ta.Task
+				task.Billable = ta.Billable
+				tasks = append(tasks, searchTask{project: a.Project, task: task})
+			}
+		}
+		return searchIndexMsg{tasks: tasks}
+	}
+}
+
+// Open the global task search, loading the task index the first time
+func (m Model) openSearch() (tea.Model, tea.Cmd) {
+	m.searchReturn = m.state
+	m.error = ""
+	m.success = ""
+
+	if m.searchTasks == nil {
+		m.state = "loading_search"
+		return m, fetchSearchIndex(m.harvestClient)
+	}
+
+	m.state = "search_tasks"
+	return m, nil
+}
+
+// Fill the search list, keeping the list's position when refreshed
+func (m *Model) setSearchItems(tasks []searchTask) {
+	m.searchTasks = tasks
+
+	// The item ID is the index into searchTasks, since task IDs repeat
+	// across projects
+	items := make([]list.Item, len(tasks))
+	for i, t := range tasks {
+		detail := t.project.Name
+		if t.project.Client != "" {
+			detail += " (" + t.project.Client + ")"
+		}
+		items[i] = ListItem{ID: i, Name: t.task.Name, Detail: detail}
+	}
+	m.searchList.SetItems(items)
+	m.searchList.Title = fmt.Sprintf("Search Tasks · %d across all projects", len(tasks))
+
+	if m.state == "loading_search" {
+		m.state = "search_tasks"
+	}
+}
+
+// Pick the highlighted task, which also decides the project
+func (m Model) selectSearchResult() (tea.Model, tea.Cmd) {
+	item, ok := m.searchList.SelectedItem().(ListItem)
+	if !ok || item.ID >= len(m.searchTasks) {
+		return m, nil
+	}
+	picked := m.searchTasks[item.ID]
+
+	// Going back from the notes shows the project's tasks as usual
+	m.selectedProject = picked.project
+	m.tasks = nil
+	for _, t := range m.searchTasks {
+		if t.project.ID == picked.project.ID {
+			m.tasks = append(m.tasks, t.task)
+		}
+	}
+	m.setTaskItems()
+	m.resizeLists()
+
+	m.selectedTask = picked.task
+	m.state = "enter_details"
+	m.ticketInput.Focus()
+	m.historyCursor = 0
+	m.restoreDraft()
+	return m, nil
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Kinds of settings, which decide how a setting is edited
+const (
+	settingToggle = iota
+	settingChoice
+	settingNumber
+	settingText
+)
+
+// setting describes one option on the settings screen
+type setting struct {
+	group   string
+	label   string
+	kind    int
+	choices []string
+	restart bool
+	get     func(c *Configuration) string
+	set     func(c *Configuration, value string) error
+}
+
+// Settings editable from the TUI, in display order
+var settings = []setting{
+	{
+		group: "Timer", label: "High precision (seconds)", kind: settingToggle,
+		get: func(c *Configuration) string { return onOff(c.HighPrecision) },
+		set: func(c *Configuration, v string) error { c.HighPrecision = v == "on"; return nil },
+	},
+	{
+		group: "Timer", label: "Display refresh (seconds)", kind: settingNumber,
+		get: func(c *Configuration) string { return strconv.Itoa(int(c.tickInterval().Seconds())) },
+		set: func(c *Configuration, v string) error { return setSeconds(&c.TickIntervalSeconds, v) },
+	},
+	{
+		group: "Timer", label: "Server check (seconds)", kind: settingNumber,
+		get: func(c *Configuration) string { return strconv.Itoa(int(c.reconcileInterval().Seconds())) },
+		set: func(c *Configuration, v string) error { return setSeconds(&c.ReconcileIntervalSeconds, v) },
+	},
+	{
+		group: "Timer", label: "Minimum to log (minutes)", kind: settingNumber,
+		get: func(c *Configuration) string { return strconv.Itoa(c.MinimumMinutes) },
+		set: func(c *Configuration, v string) error { return setNumber(&c.MinimumMinutes, v) },
+	},
+	{
+		group: "Timer", label: "Switch grace (seconds)", kind: settingNumber,
+		get: func(c *Configuration) string { return strconv.Itoa(c.SwitchGraceSeconds) },
+		set: func(c *Configuration, v string) error { return setNumber(&c.SwitchGraceSeconds, v) },
+	},
+	{
+		group: "Timer", label: "Idle auto-stop (minutes, 0 = off)", kind: settingNumber,
+		get: func(c *Configuration) string { return strconv.Itoa(c.IdleStopMinutes) },
+		set: func(c *Configuration, v string) error { return setNumber(&c.IdleStopMinutes, v) },
+	},
+	{
+		group: "Timer", label: "Confirm idle auto-stop", kind: settingToggle,
+		get: func(c *Configuration) string { return onOff(c.ConfirmIdleStop) },
+		set: func(c *Configuration, v string) error { c.ConfirmIdleStop = v == "on"; return nil },
+	},
+	{
+		group: "Timer", label: "Save draft notes", kind: settingToggle,
+		get: func(c *Configuration) string { return onOff(!c.DisableDrafts) },
+		set: func(c *Configuration, v string) error { c.DisableDrafts = v != "on"; return nil },
+	},
+	{
+		group: "Timer", label: "Flag entries without notes", kind: settingToggle,
+		get: func(c *Configuration) string { return onOff(c.RequireNotes) },
+		set: func(c *Configuration, v string) error { c.RequireNotes = v == "on"; return nil },
+	},
+	{
+		group: "Timer", label: "Confirm deleting entries", kind: settingChoice,
+		choices: []string{deleteConfirmAlways, deleteConfirmLong, deleteConfirmNever},
+		get: func(c *Configuration) string {
+			if c.DeleteConfirm == "" {
+				return deleteConfirmAlways
+			}
+			return c.DeleteConfirm
+		},
+		set: func(c *Configuration, v string) error { c.DeleteConfirm = v; return nil },
+	},
+	{
+		group: "Display", label: "Preview before starting", kind: settingToggle,
+		get: func(c *Configuration) string { return onOff(!c.HidePreview) },
+		set: func(c *Configuration, v string) error { c.HidePreview = v != "on"; return nil },
+	},
+	{
+		group: "Display", label: "Today panel on startup", kind: settingToggle, restart: true,
+		get: func(c *Configuration) string { return onOff(c.ShowToday) },
+		set: func(c *Configuration, v string) error { c.ShowToday = v == "on"; return nil },
+	},
+	{
+		group: "Display", label: "List density", kind: settingChoice, restart: true,
+		choices: []string{densityComfortable, densityCompact},
+		get: func(c *Configuration) string {
+			if c.ListDensity == "" {
+				return densityComfortable
+			}
+			return c.ListDensity
+		},
+		set: func(c *Configuration, v string) error { c.ListDensity = v; return nil },
+	},
+	{
+		group: "Display", label: "List filter matching", kind: settingChoice, restart: true,
+		choices: []string{filterRanked, filterFuzzy},
+		get: func(c *Configuration) string {
+			if c.ListFilter == "" {
+				return filterRanked
+			}
+			return c.ListFilter
+		},
+		set: func(c *Configuration, v string) error { c.ListFilter = v; return nil },
+	},
+	{
+		group: "Display", label: "Week starts on", kind: settingChoice,
+		choices: []string{"mon", "sun", "sat"},
+		get: func(c *Configuration) string {
+			if c.WeekStart == "" {
+				return "mon"
+			}
+			return weekdayKey(c.WeekStart)
+		},
+		set: func(c *Configuration, v string) error { c.WeekStart = v; return nil },
+	},
+	{
+		group: "Display", label: "Numbered quick-select (1-9)", kind: settingToggle, restart: true,
+		get: func(c *Configuration) string { return onOff(!c.DisableQuickSelect) },
+		set: func(c *Configuration, v string) error { c.DisableQuickSelect = v != "on"; return nil },
+	},
+	{
+		group: "Feedback", label: "Timer start/stop feedback", kind: settingChoice,
+		choices: []string{feedbackInline, feedbackBanner, feedbackFlash},
+		get: func(c *Configuration) string {
+			if c.Feedback == "" {
+				return feedbackInline
+			}
+			return c.Feedback
+		},
+		set: func(c *Configuration, v string) error { c.Feedback = v; return nil },
+	},
+	{
+		group: "Feedback", label: "Reduce motion", kind: settingToggle,
+		get: func(c *Configuration) string { return onOff(c.ReduceMotion) },
+		set: func(c *Configuration, v string) error { c.ReduceMotion = v == "on"; return nil },
+	},
+	{
+		group: "Work hours", label: "Confirm off-hours starts", kind: settingToggle,
+		get: func(c *Configuration) string { return onOff(c.ConfirmOffHours) },
+		set: func(c *Configuration, v string) error { c.ConfirmOffHours = v == "on"; return nil },
+	},
+	{
+		group: "Work hours", label: "Start (HH:MM)", kind: settingText,
+		get: func(c *Configuration) string { return c.WorkHours.withDefaults().Start },
+		set: func(c *Configuration, v string) error { c.WorkHours.Start = v; return c.WorkHours.validate() },
+	},
+	{
+		group: "Work hours", label: "End (HH:MM)", kind: settingText,
+		get: func(c *Configuration) string { return c.WorkHours.withDefaults().End },
+		set: func(c *Configuration, v string) error { c.WorkHours.End = v; return c.WorkHours.validate() },
+	},
+	{
+		group: "Work hours", label: "Days", kind: settingText,
+		get: func(c *Configuration) string { return strings.Join(c.WorkHours.withDefaults().Days, ",") },
+		set: func(c *Configuration, v string) error {
+			c.WorkHours.Days = strings.Split(strings.ReplaceAll(v, " ", ""), ",")
+			return c.WorkHours.validate()
+		},
+	},
+	{
+		group: "Account", label: "Subdomain", kind: settingText,
+		get: func(c *Configuration) string { return c.Subdomain },
+		set: func(c *Configuration, v string) error { c.Subdomain = v; return nil },
+	},
+	{
+		group: "Account", label: "Page size", kind: settingNumber, restart: true,
+		get: func(c *Configuration) string { return strconv.Itoa(c.perPage()) },
+		set: func(c *Configuration, v string) error { return setNumber(&c.PerPage, v) },
+	},
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+func setNumber(field *int, value string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return fmt.Errorf("%q is not a valid number", value)
+	}
+	*field = n
+	return nil
+}
+
+func setSeconds(field *int, value string) error {
+	if err := setNumber(field, value); err != nil {
+		return err
+	}
+	if *field == 0 {
+		return fmt.Errorf("Interval must be at least 1 second")
+	}
+	return nil
+}
+
+// Open the settings screen on a draft copy of the configuration
+func (m Model) openSettings() (tea.Model, tea.Cmd) {
+	m.settingsReturn = m.state
+	m.state = "settings"
+	m.settingsDraft = m.config
+	m.settingsCursor = 0
+	m.error = ""
+	m.success = ""
+	return m, nil
+}
+
+// Handle keys on the settings screen
+func (m Model) updateSettings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	current := settings[m.settingsCursor]
+
+	// Editing a number or text value in place
+	if m.settingsInput.Focused() {
+		switch msg.String() {
+		case "esc":
+			m.settingsInput.Blur()
+			return m, nil
+		case "enter":
+			if err := current.set(&m.settingsDraft, strings.TrimSpace(m.settingsInput.Value())); err != nil {
+				m.error = err.Error()
+				return m, nil
+			}
+			m.error = ""
+			m.settingsInput.Blur()
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.settingsInput, cmd = m.settingsInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc":
+		// Leave without saving
+		m.error = ""
+		m.state = m.settingsReturn
+	case "up", "k":
+		if m.settingsCursor > 0 {
+			m.settingsCursor--
+		}
+	case "down", "j":
+		if m.settingsCursor < len(settings)-1 {
+			m.settingsCursor++
+		}
+	case "enter", " ", "right", "l":
+		switch current.kind {
+		case settingToggle:
+			current.set(&m.settingsDraft, onOff(current.get(&m.settingsDraft) != "on"))
+		case settingChoice:
+			value := current.get(&m.settingsDraft)
+			next := current.choices[0]
+			for i, choice := range current.choices {
+				if choice == value {
+					next = current.choices[(i+1)%len(current.choices)]
+				}
+			}
+			current.set(&m.settingsDraft, next)
+		default:
+			if msg.String() == "enter" {
+				m.settingsInput.SetValue(current.get(&m.settingsDraft))
+				m.settingsInput.CursorEnd()
+				m.settingsInput.Focus()
+				return m, textinput.Blink
+			}
+		}
+	case "s":
+		return m.saveSettings()
+	}
+
+	return m, nil
+}
+
+// Validate and save the draft, applying settings that take effect live
+func (m Model) saveSettings() (tea.Model, tea.Cmd) {
+	if err := m.settingsDraft.validate(); err != nil {
+		m.error = err.Error()
+		return m, nil
+	}
+
+	var restart []string
+	for _, s := range settings {
+		if s.restart && s.get(&m.config) != s.get(&m.settingsDraft) {
+			restart = append(restart, s.label)
+		}
+	}
+
+	subdomainChanged := m.config.Subdomain != m.settingsDraft.Subdomain
+	m.config = m.settingsDraft
+	m.error = ""
+	m.state = m.settingsReturn
+
+	cmds := []tea.Cmd{}
+	if subdomainChanged {
+		m.webBase = accountWebBase(m.config)
+		cmds = append(cmds, m.lookupWebBase())
+	}
+
+	message := "Settings saved"
+	if len(restart) > 0 {
+		message += ". Restart to apply: " + strings.Join(restart, ", ")
+	}
+	return m, tea.Batch(append(cmds, saveConfigCmd(m.config, message))...)
+}
+
+// Render the settings screen
+func (m Model) settingsView() string {
+	var b strings.Builder
+	b.WriteString("Settings\n")
+
+	group := ""
+	for i, s := range settings {
+		if s.group != group {
+			group = s.group
+			b.WriteString("\n" + infoStyle.Render(group) + "\n")
+		}
+
+		value := s.get(&m.settingsDraft)
+		if i == m.settingsCursor && m.settingsInput.Focused() {
+			value = m.settingsInput.View()
+		}
+		if s.restart {
+			value += infoStyle.Render("  (restart required)")
+		}
+
+		line := fmt.Sprintf("  %-28s %s", s.label, value)
+		if i == m.settingsCursor {
+			line = successStyle.Render(">") + line[1:]
+		}
+		b.WriteString(line + "\n")
+	}
+
+	return b.String()
+}
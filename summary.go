@@ -0,0 +1,499 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// entryCache holds the entries of one summary period and when they were
+// last synced, so later refreshes only fetch entries updated since then.
+// Harvest doesn't report deletions this way, so entries deleted elsewhere
+// remain until the next full sync on restart. Days that failed to load are
+// kept in failed until a retry fetches them.
+type entryCache struct {
+	entries  map[int]TimeEntry
+	lastSync time.Time
+	failed   []string
+}
+
+// Delay before fetching after moving the summary date, so holding an arrow
+// key fetches only the day it stops on
+const summaryNavDelay = 300 * time.Millisecond
+
+type summaryNavMsg struct{ id int }
+
+type entriesMsg struct {
+	key      string
+	entries  []TimeEntry
+	syncedAt time.Time
+	full     bool
+	failed   []string // days that could not be loaded
+	retry    bool     // a retry of failed days only
+}
+
+// Command to fetch the entries of a period, only those updated after
+// since unless it is zero. When the range fails as a whole, each day is
+// fetched on its own so one bad page doesn't lose the rest of the week.
+func fetchEntries(client *HarvestClient, key, from, to string, since time.Time) tea.Cmd {
+	return func() tea.Msg {
+		// Taken before the request so nothing updated during it is missed
+		syncedAt := time.Now()
+		msg := entriesMsg{key: key, syncedAt: syncedAt, full: since.IsZero()}
+
+		entries, err := client.GetTimeEntries(from, to, since)
+		if err == nil {
+			msg.entries = entries
+			return msg
+		}
+		if from == to {
+			return errorMsg{error: err.Error()}
+		}
+
+		msg.entries, msg.failed = fetchDays(client, periodDays(from, to), since)
+		if len(msg.entries) == 0 && len(msg.failed) > 0 && len(msg.failed) == len(periodDays(from, to)) {
+			return errorMsg{error: err.Error()}
+		}
+		return msg
+	}
+}
+
+// Command to fetch only the days that failed in an earlier sync
+func retryFailedDays(client *HarvestClient, key string, days []string) tea.Cmd {
+	return func() tea.Msg {
+		entries, failed := fetchDays(client, days, time.Time{})
+		return entriesMsg{key: key, entries: entries, failed: failed, retry: true}
+	}
+}
+
+// Fetch days one at a time, returning the entries and the days that failed
+func fetchDays(client *HarvestClient, days []string, since time.Time) (entries []TimeEntry, failed []string) {
+	for _, day := range days {
+		dayEntries, err := client.GetTimeEntries(day, day, since)
+		if err != nil {
+			failed = append(failed, day)
+			continue
+		}
+		entries = append(entries, dayEntries...)
+	}
+	return entries, failed
+}
+
+// Dates from one day to another, inclusive
+func periodDays(from, to string) []string {
+	start, _ := time.Parse("2006-01-02", from)
+	end, _ := time.Parse("2006-01-02", to)
+
+	var days []string
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		days = append(days, d.Format("2006-01-02"))
+	}
+	return days
+}
+
+// First day of the week containing a date, for weeks starting on first
+func weekStart(date time.Time, first time.Weekday) time.Time {
+	return date.AddDate(0, 0, -((int(date.Weekday()) - int(first) + 7) % 7))
+}
+
+// Summary being shown, also while the bulk edit prompt is open over it
+func (m Model) summaryState() string {
+	if m.state == "bulk_edit" {
+		return m.bulkReturn
+	}
+	return m.state
+}
+
+// Date range and cache key of the summary being shown
+func (m Model) summaryPeriod() (key, from, to string) {
+	date, _ := time.Parse("2006-01-02", m.summaryDate)
+	if m.summaryState() == "weekly_summary" {
+		start := weekStart(date, m.config.firstWeekday())
+		from = start.Format("2006-01-02")
+		to = start.AddDate(0, 0, 6).Format("2006-01-02")
+		return "week:" + from, from, to
+	}
+	return "day:" + m.summaryDate, m.summaryDate, m.summaryDate
+}
+
+// Sync the shown summary, fully the first time and as a delta afterwards
+func (m Model) syncSummary() tea.Cmd {
+	key, from, to := m.summaryPeriod()
+
+	var since time.Time
+	if cache, ok := m.entryCache[key]; ok {
+		since = cache.lastSync
+	}
+
+	return fetchEntries(m.harvestClient, key, from, to, since)
+}
+
+// Merge fetched entries into the cache for their period
+func (m *Model) mergeEntries(msg entriesMsg) {
+	cache, ok := m.entryCache[msg.key]
+	if !ok || msg.full {
+		cache = &entryCache{entries: make(map[int]TimeEntry)}
+		m.entryCache[msg.key] = cache
+	}
+
+	for _, entry := range msg.entries {
+		cache.entries[entry.ID] = entry
+	}
+
+	switch {
+	case msg.retry:
+		// Retries don't cover the whole period, so the sync time stays
+		cache.failed = msg.failed
+	case msg.full:
+		cache.failed = msg.failed
+		cache.lastSync = msg.syncedAt
+	default:
+		// Later delta syncs don't fill in days missed earlier
+		for _, day := range msg.failed {
+			if !slices.Contains(cache.failed, day) {
+				cache.failed = append(cache.failed, day)
+			}
+		}
+		cache.lastSync = msg.syncedAt
+	}
+}
+
+// Days of the shown summary that could not be loaded
+func (m Model) failedDays() []string {
+	key, _, _ := m.summaryPeriod()
+	if cache, ok := m.entryCache[key]; ok {
+		return cache.failed
+	}
+	return nil
+}
+
+// Warning shown when the summary is missing days
+func (m Model) partialDataView() string {
+	failed := m.failedDays()
+	if len(failed) == 0 {
+		return ""
+	}
+
+	days := make([]string, len(failed))
+	for i, day := range failed {
+		date, _ := time.Parse("2006-01-02", day)
+		days[i] = date.Format("Mon 01-02")
+	}
+	return errorStyle.Render(fmt.Sprintf("⚠ Partial data — entries for %s could not be loaded (%s to retry them)",
+		strings.Join(days, ", "), m.keys.label("refresh"))) + "\n\n"
+}
+
+// Cached entries of a period in chronological order, nil if never synced
+func (m Model) cachedEntries(key string) []TimeEntry {
+	cache, ok := m.entryCache[key]
+	if !ok {
+		return nil
+	}
+
+	entries := make([]TimeEntry, 0, len(cache.entries))
+	for _, entry := range cache.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].SpentDate != entries[j].SpentDate {
+			return entries[i].SpentDate < entries[j].SpentDate
+		}
+		return entries[i].ID < entries[j].ID
+	})
+
+	return entries
+}
+
+// Open a summary for today, returning to the current screen on Esc
+func (m Model) openSummary(state string) (tea.Model, tea.Cmd) {
+	m.summaryReturn = m.state
+	m.state = state
+	m.summaryDate = time.Now().Format("2006-01-02")
+	m.summaryCursor = 0
+	m.summarySelected = nil
+	m.summaryProject = Project{}
+	m.error = ""
+	m.success = ""
+
+	// Show cached entries right away while the delta sync runs
+	key, _, _ := m.summaryPeriod()
+	m.summaryEntries = m.summaryEntriesFor(key)
+	m.summaryLoading = true
+
+	return m, tea.Batch(m.syncSummary(), m.spinner.Tick)
+}
+
+// Move the summary to another date, showing cached entries right away and
+// fetching once navigation pauses
+func (m Model) moveSummaryDate(date time.Time) (tea.Model, tea.Cmd) {
+	m.summaryDate = date.Format("2006-01-02")
+	m.summaryCursor = 0
+	m.summarySelected = nil
+
+	key, _, _ := m.summaryPeriod()
+	m.summaryEntries = m.summaryEntriesFor(key)
+
+	m.summaryNavID++
+	id := m.summaryNavID
+	cmds := []tea.Cmd{tea.Tick(summaryNavDelay, func(time.Time) tea.Msg {
+		return summaryNavMsg{id: id}
+	})}
+	if !m.summaryLoading {
+		m.summaryLoading = true
+		cmds = append(cmds, m.spinner.Tick)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// Fetch the summary date the user settled on
+func (m Model) handleSummaryNav(msg summaryNavMsg) (tea.Model, tea.Cmd) {
+	if msg.id != m.summaryNavID {
+		return m, nil
+	}
+	if m.state != "daily_summary" && m.state != "weekly_summary" {
+		m.summaryLoading = false
+		return m, nil
+	}
+	return m, m.syncSummary()
+}
+
+// "today", "yesterday", "this week", or how far back the summary is
+func (m Model) summaryDateLabel() string {
+	date, _ := time.Parse("2006-01-02", m.summaryDate)
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+
+	if m.summaryState() == "weekly_summary" {
+		first := m.config.firstWeekday()
+		weeks := int(weekStart(today, first).Sub(weekStart(date, first)).Hours()) / (24 * 7)
+		switch weeks {
+		case 0:
+			return "this week"
+		case 1:
+			return "last week"
+		case -1:
+			return "next week"
+		}
+		if weeks < 0 {
+			return fmt.Sprintf("in %d weeks", -weeks)
+		}
+		return fmt.Sprintf("%d weeks ago", weeks)
+	}
+
+	days := int(today.Sub(date).Hours()) / 24
+	switch days {
+	case 0:
+		return "today"
+	case 1:
+		return "yesterday"
+	case -1:
+		return "tomorrow"
+	}
+	if days < 0 {
+		return fmt.Sprintf("in %d days", -days)
+	}
+	return fmt.Sprintf("%d days ago", days)
+}
+
+// Handle keys in the daily and weekly summaries
+func (m Model) updateSummary(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.summaryCursor > 0 {
+			m.summaryCursor--
+		}
+		return m, nil
+	case "down", "j":
+		if m.summaryCursor < len(m.summaryEntries)-1 {
+			m.summaryCursor++
+		}
+		return m, nil
+	}
+
+	// Marking entries for a bulk edit, only in the daily summary
+	if m.state == "daily_summary" && msg.String() == " " {
+		m.toggleEntrySelection()
+		return m, nil
+	}
+
+	switch m.keys.action(msg.String()) {
+	case "edit_notes":
+		return m.openBulkEdit()
+	case "next_missing":
+		m.nextMissingNotes()
+		return m, nil
+	case "delete":
+		return m.deleteSelectedEntry()
+	case "filter_project":
+		if m.state == "weekly_summary" {
+			m.cycleSummaryProject()
+		}
+		return m, nil
+	case "back":
+		// Clear a project filter before leaving
+		if m.state == "weekly_summary" && m.summaryProject.ID != 0 {
+			m.setSummaryProject(Project{})
+			return m, nil
+		}
+		m.state = m.summaryReturn
+		return m, nil
+	case "refresh":
+		// Retry just the days that failed, if any
+		if failed := m.failedDays(); len(failed) > 0 {
+			key, _, _ := m.summaryPeriod()
+			m.summaryLoading = true
+			return m, tea.Batch(retryFailedDays(m.harvestClient, key, failed), m.spinner.Tick)
+		}
+		if !m.summaryLoading {
+			m.summaryLoading = true
+			return m, tea.Batch(m.syncSummary(), m.spinner.Tick)
+		}
+		return m, m.syncSummary()
+	case "copy_url":
+		if entry, ok := m.selectedEntry(); ok {
+			return m.copyEntryURL(entry.SpentDate, entry.ExternalReference)
+		}
+		return m, nil
+	}
+
+	// Periods step a day or a week, the week jumps are for the daily
+	// summary, and today returns to the current day or week. These come
+	// after the actions above so remapping one of those onto a period key
+	// isn't shadowed.
+	step := 1
+	if m.state == "weekly_summary" {
+		step = 7
+	}
+	date, _ := time.Parse("2006-01-02", m.summaryDate)
+	switch m.keys.summaryAction(msg.String()) {
+	case "prev_period":
+		return m.moveSummaryDate(date.AddDate(0, 0, -step))
+	case "next_period":
+		return m.moveSummaryDate(date.AddDate(0, 0, step))
+	case "prev_week":
+		return m.moveSummaryDate(date.AddDate(0, 0, -7))
+	case "next_week":
+		return m.moveSummaryDate(date.AddDate(0, 0, 7))
+	case "summary_today":
+		return m.moveSummaryDate(time.Now())
+	}
+
+	return m, nil
+}
+
+// Entry under the cursor in the summary
+func (m Model) selectedEntry() (TimeEntry, bool) {
+	if m.summaryCursor < 0 || m.summaryCursor >= len(m.summaryEntries) {
+		return TimeEntry{}, false
+	}
+	return m.summaryEntries[m.summaryCursor], true
+}
+
+// Render the daily or weekly summary
+func (m Model) summaryView() string {
+	var b strings.Builder
+
+	weekly := m.summaryState() == "weekly_summary"
+
+	loading := ""
+	if m.summaryLoading {
+		loading = " " + m.spinner.View()
+	}
+
+	_, from, to := m.summaryPeriod()
+	if weekly {
+		start, _ := time.Parse("2006-01-02", from)
+		end, _ := time.Parse("2006-01-02", to)
+		fmt.Fprintf(&b, "Weekly summary · %s to %s · %s%s\n\n",
+			start.Format("Mon 2006-01-02"), end.Format("Mon 2006-01-02"), m.summaryDateLabel(), loading)
+		b.WriteString(m.summaryFilterView())
+	} else {
+		date, _ := time.Parse("2006-01-02", from)
+		fmt.Fprintf(&b, "Daily summary · %s · %s%s\n\n", date.Format("Mon 2006-01-02"), m.summaryDateLabel(), loading)
+	}
+
+	if m.summaryEntries == nil {
+		b.WriteString("Loading entries...\n")
+		return b.String()
+	}
+	b.WriteString(m.partialDataView())
+	if len(m.summaryEntries) == 0 {
+		if weekly && m.summaryProject.ID != 0 {
+			b.WriteString(infoStyle.Render("No time tracked on "+m.summaryProject.Name+" in this period") + "\n")
+			return b.String()
+		}
+		b.WriteString(infoStyle.Render("No time tracked in this period") + "\n")
+		return b.String()
+	}
+
+	b.WriteString(m.missingNotesView())
+
+	dayTotals := make(map[string]float64)
+	for _, entry := range m.summaryEntries {
+		dayTotals[entry.SpentDate] += m.entryHours(entry)
+	}
+
+	var total float64
+	for i, entry := range m.summaryEntries {
+		// Group the weekly summary by day
+		if weekly && (i == 0 || m.summaryEntries[i-1].SpentDate != entry.SpentDate) {
+			date, _ := time.Parse("2006-01-02", entry.SpentDate)
+			b.WriteString(infoStyle.Render(fmt.Sprintf("%s %s · %.2fh",
+				date.Format("Mon"), entry.SpentDate, dayTotals[entry.SpentDate])) + "\n")
+		}
+
+		hours := m.entryHours(entry)
+		total += hours
+
+		cursor := "  "
+		if i == m.summaryCursor {
+			cursor = "> "
+		}
+		if len(m.summarySelected) > 0 {
+			if m.summarySelected[entry.ID] {
+				cursor += "[x] "
+			} else {
+				cursor += "[ ] "
+			}
+		}
+
+		flag := " "
+		if m.missingNotes(entry) {
+			flag = "!"
+		}
+
+		line := fmt.Sprintf("%s%s%5.2fh  %s / %s", cursor, flag, hours, entry.Project.Name, entry.Task.Name)
+		if entry.Notes != "" {
+			line += " — " + entry.Notes
+		}
+		if m.width > 0 {
+			line = ansi.Truncate(line, m.width-docStyle.GetHorizontalFrameSize(), "…")
+		}
+		if i == m.summaryCursor {
+			line = successStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if weekly && m.summaryProject.ID != 0 {
+		b.WriteString("\n" + successStyle.Bold(true).Render(fmt.Sprintf("%s total: %.2fh", m.summaryProject.Name, total)) + "\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "\nTotal: %.2fh\n", total)
+	return b.String()
+}
+
+// Keep the summary spinner turning while entries load
+func (m Model) handleSpinner(msg spinner.TickMsg) (tea.Model, tea.Cmd) {
+	if !m.summaryLoading {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return m, cmd
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func mustDate(s string) time.Time {
+	d, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestWeekStart(t *testing.T) {
+	tests := []struct {
+		date  string
+		first time.Weekday
+		want  string
+	}{
+		// Wednesday 12 March 2025 for every first weekday
+		{"2025-03-12", time.Sunday, "2025-03-09"},
+		{"2025-03-12", time.Monday, "2025-03-10"},
+		{"2025-03-12", time.Tuesday, "2025-03-11"},
+		{"2025-03-12", time.Wednesday, "2025-03-12"},
+		{"2025-03-12", time.Thursday, "2025-03-06"},
+		{"2025-03-12", time.Friday, "2025-03-07"},
+		{"2025-03-12", time.Saturday, "2025-03-08"},
+
+		// The week start itself, and the day before it
+		{"2025-03-09", time.Sunday, "2025-03-09"},
+		{"2025-03-10", time.Monday, "2025-03-10"},
+		{"2025-03-09", time.Monday, "2025-03-03"},
+		{"2025-03-08", time.Sunday, "2025-03-02"},
+
+		// Weeks crossing a month and a year
+		{"2025-04-02", time.Monday, "2025-03-31"},
+		{"2025-03-01", time.Sunday, "2025-02-23"},
+		{"2025-01-01", time.Monday, "2024-12-30"},
+		{"2026-01-03", time.Sunday, "2025-12-28"},
+
+		// Leap day
+		{"2024-03-02", time.Monday, "2024-02-26"},
+	}
+	for _, tt := range tests {
+		got := weekStart(mustDate(tt.date), tt.first).Format("2006-01-02")
+		if got != tt.want {
+			t.Errorf("weekStart(%s, %s) = %s, want %s", tt.date, tt.first, got, tt.want)
+		}
+	}
+}
+
+func TestReportPeriodWeek(t *testing.T) {
+	for _, tt := range []struct {
+		first    time.Weekday
+		from, to string
+	}{
+		{time.Monday, "2025-03-10", "2025-03-16"},
+		{time.Sunday, "2025-03-09", "2025-03-15"},
+	} {
+		from, to, err := reportPeriod("week", mustDate("2025-03-12"), tt.first)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := from.Format("2006-01-02") + " to " + to.Format("2006-01-02"); got != tt.from+" to "+tt.to {
+			t.Errorf("%s weeks: %s, want %s to %s", tt.first, got, tt.from, tt.to)
+		}
+	}
+}
+
+func TestSyncSummaryFetchesDelta(t *testing.T) {
+	var mu sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Has("updated_since") {
+			w.Write([]byte(`{"time_entries": [{"id": 2, "spent_date": "2025-03-10", "hours": 3, "notes": "changed"}]}`))
+			return
+		}
+		w.Write([]byte(`{"time_entries": [
+			{"id": 1, "spent_date": "2025-03-10", "hours": 1},
+			{"id": 2, "spent_date": "2025-03-10", "hours": 2}
+		]}`))
+	}))
+	defer server.Close()
+
+	m := Model{
+		harvestClient: NewHarvestClient(Configuration{BaseURL: server.URL + "/v2"}),
+		entryCache:    make(map[string]*entryCache),
+		state:         "daily_summary",
+		summaryDate:   "2025-03-10",
+	}
+
+	first := m.syncSummary()().(entriesMsg)
+	if !first.full {
+		t.Error("first sync not a full fetch")
+	}
+	m.mergeEntries(first)
+
+	delta := m.syncSummary()().(entriesMsg)
+	if delta.full {
+		t.Error("second sync was a full fetch")
+	}
+	m.mergeEntries(delta)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(queries) != 2 {
+		t.Fatalf("%d requests, want 2", len(queries))
+	}
+	if strings.Contains(queries[0], "updated_since") {
+		t.Errorf("first sync asked for a delta: %s", queries[0])
+	}
+	since := first.syncedAt.UTC().Format(time.RFC3339)
+	if !strings.Contains(queries[1], "updated_since="+url.QueryEscape(since)) {
+		t.Errorf("second sync query %s doesn't ask for entries updated since %s", queries[1], since)
+	}
+
+	entries := m.cachedEntries("day:2025-03-10")
+	if len(entries) != 2 || entries[0].Hours != 1 || entries[1].Notes != "changed" {
+		t.Errorf("cached entries = %+v, want the delta merged into the first sync", entries)
+	}
+}
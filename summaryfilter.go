@@ -0,0 +1,76 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// Entries of a period as the summary shows them: only the filtered
+// project's in the weekly summary when a project filter is set
+func (m Model) summaryEntriesFor(key string) []TimeEntry {
+	entries := m.cachedEntries(key)
+	if entries == nil || m.summaryProject.ID == 0 || m.summaryState() != "weekly_summary" {
+		return entries
+	}
+
+	filtered := []TimeEntry{}
+	for _, entry := range entries {
+		if entry.Project.ID == m.summaryProject.ID {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// Filter the weekly summary to the next project tracked in the period, by
+// name, going back to all projects after the last one. The period stays.
+func (m *Model) cycleSummaryProject() {
+	key, _, _ := m.summaryPeriod()
+
+	seen := make(map[int]bool)
+	var projects []Project
+	for _, entry := range m.cachedEntries(key) {
+		if !seen[entry.Project.ID] {
+			seen[entry.Project.ID] = true
+			projects = append(projects, entry.Project)
+		}
+	}
+	if len(projects) == 0 {
+		return
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return strings.ToLower(projects[i].Name) < strings.ToLower(projects[j].Name)
+	})
+
+	next := projects[0]
+	if m.summaryProject.ID != 0 {
+		next = Project{}
+		for i, project := range projects {
+			if project.ID == m.summaryProject.ID && i+1 < len(projects) {
+				next = projects[i+1]
+			}
+		}
+	}
+
+	m.setSummaryProject(next)
+}
+
+// Show only one project's entries, or all of them for the zero project
+func (m *Model) setSummaryProject(project Project) {
+	m.summaryProject = project
+	m.summaryCursor = 0
+	m.summarySelected = nil
+
+	key, _, _ := m.summaryPeriod()
+	m.summaryEntries = m.summaryEntriesFor(key)
+}
+
+// Line naming the project filter, with how to change or clear it
+func (m Model) summaryFilterView() string {
+	filter, back := m.keys.label("filter_project"), m.keys.label("back")
+	if m.summaryProject.ID == 0 {
+		return infoStyle.Render("All projects · "+filter+" to show one") + "\n\n"
+	}
+	return successStyle.Bold(true).Render("Project: "+m.summaryProject.Name) +
+		infoStyle.Render(" · "+filter+" next project · "+back+" all projects") + "\n\n"
+}
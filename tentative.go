@@ -0,0 +1,56 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The running timer's notes were saved on the server
+type notesUpdatedMsg struct {
+	timerID int
+	notes   string
+}
+
+// Command to replace the notes of a running timer
+func updateTimerNotes(client *HarvestClient, timerID int, notes string) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := client.UpdateTimeEntry(timerID, map[string]any{"notes": notes}); err != nil {
+			return errorMsg{error: err.Error()}
+		}
+		return notesUpdatedMsg{timerID: timerID, notes: notes}
+	}
+}
+
+// Stop the running timer, first asking to finalize its notes when it is
+// still marked tentative. Notes edited since starting are saved instead,
+// which finalizes the timer without stopping it.
+func (m Model) stopOrFinalize() (tea.Model, tea.Cmd) {
+	if !m.tentative {
+		return m.stopAboveMinimum()
+	}
+
+	if notes := sanitizeNotes(m.notesText()); notes != m.activeTimer.Notes {
+		return m, updateTimerNotes(m.harvestClient, m.activeTimer.ID, notes)
+	}
+
+	m.state = "confirm_tentative"
+	return m, nil
+}
+
+// Handle the "Finalize notes?" prompt shown when stopping a tentative timer
+func (m Model) updateTentativePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "e":
+		return m.focusNotes()
+	case "s":
+		// Stop anyway, keeping the notes as they are
+		m.state = "enter_details"
+		return m.stopAboveMinimum()
+	case "n", "esc":
+		m.state = "enter_details"
+	}
+	return m, nil
+}
+
+// Prompt shown when stopping a tentative timer
+const tentativePrompt = "This timer is tentative — finalize its notes before stopping?\n\n" +
+	"e = edit notes, s = stop anyway, n = cancel"
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTimerDecodesNestedProjectAndTask(t *testing.T) {
+	data := `{"id": 7, "notes": "ABC-1", "hours": 1.5, "is_running": true, "spent_date": "2024-03-04",
+		"project": {"id": 11, "name": "Website"}, "task": {"id": 22, "name": "Design"}}`
+
+	var timer Timer
+	if err := json.Unmarshal([]byte(data), &timer); err != nil {
+		t.Fatal(err)
+	}
+
+	want := Timer{ID: 7, Notes: "ABC-1", Hours: 1.5, IsRunning: true, SpentDate: "2024-03-04",
+		ProjectID: 11, TaskID: 22, ProjectName: "Website", TaskName: "Design"}
+	if timer != want {
+		t.Errorf("got %+v, want %+v", timer, want)
+	}
+}
+
+func TestTimerCarryDetailsKeepsKnownValues(t *testing.T) {
+	previous := &Timer{ID: 7, ProjectID: 11, TaskID: 22, ProjectName: "Website", TaskName: "Design"}
+
+	refetched := &Timer{ID: 7, Notes: "edited"}
+	refetched.carryDetails(previous)
+	if refetched.ProjectID != 11 || refetched.TaskID != 22 || refetched.ProjectName != "Website" || refetched.TaskName != "Design" {
+		t.Errorf("details not carried: %+v", refetched)
+	}
+
+	// Values from the server win over the local ones
+	renamed := &Timer{ID: 7, ProjectID: 11, ProjectName: "Website v2"}
+	renamed.carryDetails(previous)
+	if renamed.ProjectName != "Website v2" || renamed.TaskID != 22 {
+		t.Errorf("got %+v", renamed)
+	}
+}
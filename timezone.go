@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Harvest reports time zones by their Rails names. These are the common
+// ones; zones given as IANA names are used as they are.
+var railsZones = map[string]string{
+	"International Date Line West": "Etc/GMT+12",
+	"American Samoa":               "Pacific/Pago_Pago",
+	"Hawaii":                       "Pacific/Honolulu",
+	"Alaska":                       "America/Juneau",
+	"Pacific Time (US & Canada)":   "America/Los_Angeles",
+	"Tijuana":                      "America/Tijuana",
+	"Mountain Time (US & Canada)":  "America/Denver",
+	"Arizona":                      "America/Phoenix",
+	"Central Time (US & Canada)":   "America/Chicago",
+	"Mexico City":                  "America/Mexico_City",
+	"Saskatchewan":                 "America/Regina",
+	"Eastern Time (US & Canada)":   "America/New_York",
+	"Indiana (East)":               "America/Indiana/Indianapolis",
+	"Bogota":                       "America/Bogota",
+	"Lima":                         "America/Lima",
+	"Atlantic Time (Canada)":       "America/Halifax",
+	"Caracas":                      "America/Caracas",
+	"Santiago":                     "America/Santiago",
+	"Newfoundland":                 "America/St_Johns",
+	"Brasilia":                     "America/Sao_Paulo",
+	"Buenos Aires":                 "America/Argentina/Buenos_Aires",
+	"Montevideo":                   "America/Montevideo",
+	"Greenland":                    "America/Godthab",
+	"Mid-Atlantic":                 "Atlantic/South_Georgia",
+	"Azores":                       "Atlantic/Azores",
+	"Cape Verde Is.":               "Atlantic/Cape_Verde",
+	"UTC":                          "Etc/UTC",
+	"Edinburgh":                    "Europe/London",
+	"London":                       "Europe/London",
+	"Dublin":                       "Europe/Dublin",
+	"Lisbon":                       "Europe/Lisbon",
+	"Casablanca":                   "Africa/Casablanca",
+	"Amsterdam":                    "Europe/Amsterdam",
+	"Berlin":                       "Europe/Berlin",
+	"Bern":                         "Europe/Zurich",
+	"Zurich":                       "Europe/Zurich",
+	"Brussels":                     "Europe/Brussels",
+	"Copenhagen":                   "Europe/Copenhagen",
+	"Madrid":                       "Europe/Madrid",
+	"Paris":                        "Europe/Paris",
+	"Prague":                       "Europe/Prague",
+	"Rome":                         "Europe/Rome",
+	"Stockholm":                    "Europe/Stockholm",
+	"Vienna":                       "Europe/Vienna",
+	"Warsaw":                       "Europe/Warsaw",
+	"West Central Africa":          "Africa/Algiers",
+	"Athens":                       "Europe/Athens",
+	"Bucharest":                    "Europe/Bucharest",
+	"Cairo":                        "Africa/Cairo",
+	"Helsinki":                     "Europe/Helsinki",
+	"Jerusalem":                    "Asia/Jerusalem",
+	"Kyiv":                         "Europe/Kiev",
+	"Pretoria":                     "Africa/Johannesburg",
+	"Istanbul":                     "Europe/Istanbul",
+	"Moscow":                       "Europe/Moscow",
+	"Nairobi":                      "Africa/Nairobi",
+	"Riyadh":                       "Asia/Riyadh",
+	"Tehran":                       "Asia/Tehran",
+	"Abu Dhabi":                    "Asia/Muscat",
+	"Baku":                         "Asia/Baku",
+	"Kabul":                        "Asia/Kabul",
+	"Karachi":                      "Asia/Karachi",
+	"Tashkent":                     "Asia/Tashkent",
+	"Chennai":                      "Asia/Kolkata",
+	"Kolkata":                      "Asia/Kolkata",
+	"Mumbai":                       "Asia/Kolkata",
+	"New Delhi":                    "Asia/Kolkata",
+	"Kathmandu":                    "Asia/Kathmandu",
+	"Dhaka":                        "Asia/Dhaka",
+	"Rangoon":                      "Asia/Rangoon",
+	"Bangkok":                      "Asia/Bangkok",
+	"Jakarta":                      "Asia/Jakarta",
+	"Beijing":                      "Asia/Shanghai",
+	"Hong Kong":                    "Asia/Hong_Kong",
+	"Singapore":                    "Asia/Singapore",
+	"Taipei":                       "Asia/Taipei",
+	"Perth":                        "Australia/Perth",
+	"Seoul":                        "Asia/Seoul",
+	"Tokyo":                        "Asia/Tokyo",
+	"Osaka":                        "Asia/Tokyo",
+	"Adelaide":                     "Australia/Adelaide",
+	"Darwin":                       "Australia/Darwin",
+	"Brisbane":                     "Australia/Brisbane",
+	"Canberra":                     "Australia/Melbourne",
+	"Melbourne":                    "Australia/Melbourne",
+	"Sydney":                       "Australia/Sydney",
+	"Hobart":                       "Australia/Hobart",
+	"Guam":                         "Pacific/Guam",
+	"Auckland":                     "Pacific/Auckland",
+	"Wellington":                   "Pacific/Auckland",
+	"Fiji":                         "Pacific/Fiji",
+}
+
+type timezoneMsg struct{ warning string }
+
+// User is the Harvest user the access token belongs to
+type User struct {
+	ID        int    `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Timezone  string `json:"timezone"`
+}
+
+// Full name of the user
+func (u User) Name() string {
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
+}
+
+// Fetch the current user's profile
+func (h *HarvestClient) GetCurrentUser() (*User, error) {
+	resp, err := h.client.R().
+		SetResult(&User{}).
+		Get("/users/me")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("API error: Status=%d, Body=%s", resp.StatusCode(), resp.String())
+	}
+
+	return resp.Result().(*User), nil
+}
+
+// IANA name of a Harvest time zone
+func ianaZone(harvestZone string) (string, bool) {
+	if zone, ok := railsZones[harvestZone]; ok {
+		return zone, true
+	}
+	if _, err := time.LoadLocation(harvestZone); err == nil && harvestZone != "" {
+		return harvestZone, true
+	}
+	return "", false
+}
+
+// Use the configured time zone for dates and work hours instead of the
+// system one
+func applyTimezone(name string) error {
+	if name == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return err
+	}
+	time.Local = loc
+	return nil
+}
+
+// Compare the local time zone with the account's. Zones are compared by
+// their current UTC offset, so aliases like Europe/Paris and Europe/Berlin
+// don't count as a mismatch. Returns the account's IANA zone and a
+// description of the mismatch, empty when they agree.
+func timezoneMismatch(harvestZone string) (zone, mismatch string, err error) {
+	zone, ok := ianaZone(harvestZone)
+	if !ok {
+		return "", "", fmt.Errorf("unknown Harvest time zone %q", harvestZone)
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return "", "", err
+	}
+
+	now := time.Now()
+	localName, localOffset := now.Zone()
+	accountName, accountOffset := now.In(loc).Zone()
+	if localOffset == accountOffset {
+		return zone, "", nil
+	}
+
+	return zone, fmt.Sprintf("Local time zone %s (UTC%s) differs from your Harvest time zone %s (UTC%s); entries near midnight may land on unexpected days",
+		localName, formatOffset(localOffset), accountName, formatOffset(accountOffset)), nil
+}
+
+// UTC offset in seconds as "+02:00"
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign, seconds = "-", -seconds
+	}
+	return fmt.Sprintf("%s%02d:%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// Command to check the account time zone at startup. Failures are ignored
+// since the check is only informative.
+func checkTimezone(client *HarvestClient) tea.Cmd {
+	return func() tea.Msg {
+		user, err := client.GetCurrentUser()
+		if err != nil {
+			return nil
+		}
+		if _, mismatch, err := timezoneMismatch(user.Timezone); err == nil && mismatch != "" {
+			return timezoneMsg{warning: mismatch + " (run harvest-tui doctor to fix)"}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// Connection pool defaults. Every request goes to the same host, so allow
+// more idle connections per host than net/http's default of two, which
+// otherwise closes and redials connections during concurrent fetches.
+const (
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 10
+	defaultIdleConnTimeout     = 90 * time.Second
+	defaultKeepAlive           = 30 * time.Second
+	dialTimeout                = 30 * time.Second
+)
+
+// Build the HTTP transport for the API client from the pool settings
+func newTransport(c Configuration) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: durationOr(c.KeepAliveSeconds, defaultKeepAlive),
+	}
+	transport.DialContext = dialer.DialContext
+
+	transport.MaxIdleConns = intOr(c.MaxIdleConns, defaultMaxIdleConns)
+	transport.MaxIdleConnsPerHost = intOr(c.MaxIdleConnsPerHost, defaultMaxIdleConnsPerHost)
+	transport.IdleConnTimeout = durationOr(c.IdleConnTimeoutSeconds, defaultIdleConnTimeout)
+	transport.DisableKeepAlives = c.DisableKeepAlives
+
+	return transport
+}
+
+func intOr(value, fallback int) int {
+	if value > 0 {
+		return value
+	}
+	return fallback
+}
+
+func durationOr(seconds int, fallback time.Duration) time.Duration {
+	if seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return fallback
+}
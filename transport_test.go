@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Pages served by newPagedServer for every range
+const benchmarkPages = 5
+
+// Server answering time entry requests with benchmarkPages pages, counting
+// the connections clients open to it
+func newPagedServer(tb testing.TB) (*httptest.Server, *atomic.Int64) {
+	tb.Helper()
+	var dials atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		next := "null"
+		if page < benchmarkPages {
+			next = strconv.Itoa(page + 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"time_entries": [{"id": %d, "hours": 1}], "next_page": %s}`, page, next)
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			dials.Add(1)
+		}
+	}
+	server.Start()
+	tb.Cleanup(server.Close)
+	return server, &dials
+}
+
+func TestPaginatedFetchReusesConnection(t *testing.T) {
+	server, dials := newPagedServer(t)
+	client := NewHarvestClient(Configuration{BaseURL: server.URL + "/v2"})
+
+	entries, err := client.GetTimeEntries("2025-03-01", "2025-03-31", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != benchmarkPages {
+		t.Fatalf("%d entries, want one per page", len(entries))
+	}
+	if n := dials.Load(); n != 1 {
+		t.Errorf("%d connections for %d pages, want 1", n, benchmarkPages)
+	}
+}
+
+// Compare the connections opened over a paginated fetch with the pooled
+// transport and with keep-alive turned off, reported as dials/op
+func BenchmarkPaginatedFetch(b *testing.B) {
+	for _, bm := range []struct {
+		name   string
+		config Configuration
+	}{
+		{"pooled", Configuration{}},
+		{"no_keep_alive", Configuration{DisableKeepAlives: true}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			server, dials := newPagedServer(b)
+			bm.config.BaseURL = server.URL + "/v2"
+			client := NewHarvestClient(bm.config)
+
+			for b.Loop() {
+				if _, err := client.GetTimeEntries("2025-03-01", "2025-03-31", time.Time{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(dials.Load())/float64(b.N), "dials/op")
+		})
+	}
+}
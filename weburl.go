@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type copiedMsg struct{ text string }
+
+// Web URL for an entry: its external permalink when it has one, otherwise
+// the Harvest day view the entry was logged on
+func entryURL(webBase, spentDate string, ref *ExternalReference) (string, error) {
+	if ref != nil && ref.Permalink != "" {
+		return ref.Permalink, nil
+	}
+
+	if webBase == "" {
+		return "", fmt.Errorf("No URL for this entry: the account's web address couldn't be loaded, set \"subdomain\" in the config file")
+	}
+	if spentDate == "" {
+		return "", fmt.Errorf("No URL for this entry: its date is unknown")
+	}
+
+	return fmt.Sprintf("%s/time/day/%s", webBase, strings.ReplaceAll(spentDate, "-", "/")), nil
+}
+
+// Command to copy text to the system clipboard
+func copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(text); err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to copy to clipboard: %v", err)}
+		}
+		return copiedMsg{text: text}
+	}
+}
+
+// Copy the URL of an entry, reporting entries without a resolvable URL
+func (m Model) copyEntryURL(spentDate string, ref *ExternalReference) (tea.Model, tea.Cmd) {
+	url, err := entryURL(m.webBase, spentDate, ref)
+	if err != nil {
+		m.error = err.Error()
+		return m, nil
+	}
+
+	m.error = ""
+	return m, copyToClipboard(url)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// How long resizing has to settle before the window size is written
+const windowSaveDelay = time.Second
+
+// windowSize is the last known terminal size, used to lay out the first
+// frame before the terminal reports its size
+type windowSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Message to write the window size once resizing has settled
+type windowSaveMsg struct{ id int }
+
+// Load the last window size, ignoring a missing or corrupt file
+func loadWindowSize() (windowSize, bool) {
+	var size windowSize
+	if !windowFile.load(&size) || size.Width <= 0 || size.Height <= 0 {
+		return size, false
+	}
+	return size, true
+}
+
+// Schedule a window size write after resizing settles, so dragging the
+// window edge doesn't write on every step
+func (m *Model) scheduleWindowSave() tea.Cmd {
+	m.windowID++
+	id := m.windowID
+	return tea.Tick(windowSaveDelay, func(time.Time) tea.Msg {
+		return windowSaveMsg{id: id}
+	})
+}
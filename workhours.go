@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WorkHours is the daily working window, in local time
+type WorkHours struct {
+	Start string   `json:"start,omitempty"` // "09:00"
+	End   string   `json:"end,omitempty"`   // "18:00"
+	Days  []string `json:"days,omitempty"`  // "mon", "tue", ...
+}
+
+var (
+	defaultWorkStart = "09:00"
+	defaultWorkEnd   = "18:00"
+	defaultWorkDays  = []string{"mon", "tue", "wed", "thu", "fri"}
+)
+
+// Fill in defaults for unset fields
+func (w WorkHours) withDefaults() WorkHours {
+	if w.Start == "" {
+		w.Start = defaultWorkStart
+	}
+	if w.End == "" {
+		w.End = defaultWorkEnd
+	}
+	if len(w.Days) == 0 {
+		w.Days = defaultWorkDays
+	}
+	return w
+}
+
+// Minutes since midnight of a "15:04" clock time. Parsing accepts "9:00"
+// as well as "09:00", so times are compared as numbers, never as strings.
+func clockMinutes(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Start and end of the window in minutes since midnight
+func (w WorkHours) window() (start, end int, err error) {
+	w = w.withDefaults()
+
+	start, err = clockMinutes(w.Start)
+	if err != nil {
+		return 0, 0, fmt.Errorf("work_hours.start must be HH:MM")
+	}
+	end, err = clockMinutes(w.End)
+	if err != nil {
+		return 0, 0, fmt.Errorf("work_hours.end must be HH:MM")
+	}
+	return start, end, nil
+}
+
+// Check that the window parses and ends after it starts
+func (w WorkHours) validate() error {
+	w = w.withDefaults()
+
+	start, end, err := w.window()
+	if err != nil {
+		return err
+	}
+	if end <= start {
+		return fmt.Errorf("work_hours.end must be after work_hours.start")
+	}
+
+	for _, day := range w.Days {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("work_hours.days has unknown day %q", day)
+		}
+	}
+	return nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Whether a time falls inside the working window
+func (w WorkHours) contains(t time.Time) bool {
+	w = w.withDefaults()
+
+	workday := false
+	for _, day := range w.Days {
+		if weekdays[strings.ToLower(day)] == t.Weekday() {
+			workday = true
+		}
+	}
+	if !workday {
+		return false
+	}
+
+	// The config was validated on load
+	start, end, _ := w.window()
+	clock := t.Hour()*60 + t.Minute()
+	return clock >= start && clock < end
+}
+
+// Start the timer for the selected project/task, asking first when it is
+// outside working hours and that confirmation is enabled
+func (m Model) startWithinHours() (tea.Model, tea.Cmd) {
+	if m.startPending {
+		return m, nil
+	}
+
+	if m.config.ConfirmOffHours && !m.skipOffHours && !m.config.WorkHours.contains(time.Now()) {
+		m.state = "confirm_off_hours"
+		return m, nil
+	}
+
+	cmd := m.startSelectedTimer()
+	return m, cmd
+}
+
+// Handle the off-hours confirmation prompt
+func (m Model) updateOffHoursPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y":
+		m.state = "enter_details"
+		cmd := m.startSelectedTimer()
+		return m, cmd
+	case "a":
+		// Don't ask again for the rest of the session
+		m.skipOffHours = true
+		m.state = "enter_details"
+		cmd := m.startSelectedTimer()
+		return m, cmd
+	case "n", "esc":
+		m.state = "enter_details"
+	}
+	return m, nil
+}
+
+// Prompt shown before starting a timer outside working hours
+func offHoursPrompt(now time.Time) string {
+	return fmt.Sprintf("It's %s on %s, outside your working hours — start anyway?\n\n"+
+		"y = start, n = cancel, a = start and don't ask again this session",
+		now.Format("3:04pm"), now.Format("Monday"))
+}
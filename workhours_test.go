@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// Monday 10 March 2025 at a clock time
+func mondayAt(hour, minute int) time.Time {
+	return time.Date(2025, 3, 10, hour, minute, 0, 0, time.Local)
+}
+
+func TestWorkHoursContains(t *testing.T) {
+	hours := WorkHours{Start: "9:00", End: "17:30"}
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{mondayAt(8, 59), false},
+		{mondayAt(9, 0), true},
+		{mondayAt(9, 15), true},
+		{mondayAt(10, 0), true},
+		{mondayAt(17, 29), true},
+		{mondayAt(17, 30), false},
+		{mondayAt(9, 15).AddDate(0, 0, 5), false}, // Saturday
+	}
+	for _, tt := range tests {
+		if got := hours.contains(tt.at); got != tt.want {
+			t.Errorf("contains(%s) = %v, want %v", tt.at.Format("Mon 15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestWorkHoursValidate(t *testing.T) {
+	tests := []struct {
+		hours WorkHours
+		ok    bool
+	}{
+		{WorkHours{}, true},
+		{WorkHours{Start: "9:00", End: "10:00"}, true},
+		{WorkHours{Start: "10:00", End: "9:00"}, false},
+		{WorkHours{Start: "09:00", End: "09:00"}, false},
+		{WorkHours{Start: "nine"}, false},
+		{WorkHours{End: "25:00"}, false},
+		{WorkHours{Days: []string{"Mon", "funday"}}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.hours.validate(); (err == nil) != tt.ok {
+			t.Errorf("validate(%+v) = %v, want ok %v", tt.hours, err, tt.ok)
+		}
+	}
+}
+
+func TestNudgeWithSingleDigitStart(t *testing.T) {
+	m := newTestModel(t)
+	m.state = "select_project"
+	m.config.FocusNudge = true
+	m.config.WorkHours = WorkHours{Start: "9:00", End: "17:00"}
+
+	if m.nudgeOnFocus(mondayAt(9, 30)) == nil || !m.nudge {
+		t.Error("no nudge at 9:30 with work hours from 9:00")
+	}
+}